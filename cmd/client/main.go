@@ -3,24 +3,46 @@ package main
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
+	"sort"
 	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
 
 	"github.com/urfave/cli/v2"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"job_runner/lib/jobs"
 	"job_runner/lib/utils"
+	"job_runner/pkg/ptyz"
+	"job_runner/proto"
 )
 
 func main() {
 	app := cli.NewApp()
 	app.Commands = []*cli.Command{
+		clientGetCommand,
+		clientStatsCommand,
+		clientTopCommand,
 		clientListCommand,
 		clientStartCommand,
 		clientStopCommand,
 		clientStreamCommand,
+		clientAttachCommand,
+		clientEventsCommand,
+		clientWaitCommand,
+		clientKillCommand,
+		clientDeleteCommand,
+		clientPriorityCommand,
+		clientScheduleCommand,
+		clientWorkflowCommand,
 	}
 	app.Flags = []cli.Flag{
 		&cli.StringFlag{
@@ -86,10 +108,10 @@ func (c ClientConfig) Build(ctx context.Context) (*jobs.Client, error) {
 	if err != nil {
 		return nil, err
 	}
-	return jobs.NewClient(cc, os.Stdout), nil
+	return jobs.NewClient(cc, os.Stdout, os.Stderr), nil
 }
 
-var clientListCommand = &cli.Command{
+var clientGetCommand = &cli.Command{
 	Name: "get",
 	Flags: []cli.Flag{
 		&cli.IntFlag{
@@ -109,13 +131,238 @@ var clientListCommand = &cli.Command{
 		if err != nil {
 			return err
 		}
-		fmt.Printf("id: %d cmd: %s\n", job.GetId(), strings.Join(job.GetCmd(), " "))
+		fmt.Printf("id: %d cmd: %s status: %s exit code: %d", job.GetId(), strings.Join(job.GetCmd(), " "), job.GetStatus(), job.GetExitCode())
+		if job.GetSignal() != "" {
+			fmt.Printf(" signal: %s", job.GetSignal())
+		}
+		fmt.Printf(" output limit: %d policy: %s truncated: %d", job.GetOutputLimit(), outputPolicyName(job.GetOutputPolicy()), job.GetOutputTruncated())
+		fmt.Printf(" priority: %d", job.GetPriority())
+		if job.GetQueuePosition() > 0 {
+			fmt.Printf(" queue position: %d", job.GetQueuePosition())
+		}
+		if job.GetMaxAttempts() > 1 {
+			fmt.Printf(" attempt: %d/%d", job.GetAttempt(), job.GetMaxAttempts())
+		}
+		if job.GetRetryAt() != nil {
+			fmt.Printf(" retry at: %s", formatTimestamp(job.GetRetryAt()))
+		}
+		if job.GetTimeout() != nil {
+			fmt.Printf(" timeout: %s", job.GetTimeout().AsDuration())
+		}
+		if events := job.GetMemoryEvents(); events != nil {
+			fmt.Printf(" memory max: %d oom: %d oom kill: %d", events.GetMax(), events.GetOom(), events.GetOomKill())
+		}
+		if job.GetSummary() {
+			fmt.Print(" (output removed)")
+		}
+		fmt.Println()
+		for _, attempt := range job.GetAttempts() {
+			fmt.Printf("  attempt %d: status: %s exit code: %d", attempt.GetNumber(), attempt.GetStatus(), attempt.GetExitCode())
+			if attempt.GetSignal() != "" {
+				fmt.Printf(" signal: %s", attempt.GetSignal())
+			}
+			fmt.Printf(" started: %s ended: %s\n", formatTimestamp(attempt.GetStartedAt()), formatTimestamp(attempt.GetEndedAt()))
+		}
+		return nil
+	},
+}
+
+var clientStatsCommand = &cli.Command{
+	Name:  "stats",
+	Usage: "show the resource usage of a running job, or the summary of the usage of a job that has ended",
+	Flags: []cli.Flag{
+		&cli.IntFlag{
+			Name:     "id",
+			Required: true,
+		},
+	},
+	Action: func(c *cli.Context) error {
+		ctx := c.Context
+		clientConf := GetDefaultConfigFromCLI(c)
+		client, err := clientConf.Build(ctx)
+		if err != nil {
+			return fmt.Errorf("Build: %w", err)
+		}
+		stats, err := client.Stats(ctx, int32(c.Int("id")))
+		if err != nil {
+			return err
+		}
+		printStats(stats)
 		return nil
 	},
 }
 
+var clientTopCommand = &cli.Command{
+	Name:  "top",
+	Usage: "show the resource usage of a running job as it changes, until the job ends",
+	Flags: []cli.Flag{
+		&cli.IntFlag{
+			Name:     "id",
+			Required: true,
+		},
+		&cli.DurationFlag{
+			Name:  "interval",
+			Usage: "time between samples, the server defaults to 1s",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		ctx, cancel := signal.NotifyContext(c.Context, os.Interrupt)
+		defer cancel()
+		clientConf := GetDefaultConfigFromCLI(c)
+		client, err := clientConf.Build(ctx)
+		if err != nil {
+			return fmt.Errorf("Build: %w", err)
+		}
+		err = client.Top(ctx, int32(c.Int("id")), c.Duration("interval"))
+		if ctx.Err() != nil {
+			return nil
+		}
+		return err
+	},
+}
+
+// printStats prints the stats of a job, only the summary for a job that has ended
+func printStats(stats *proto.JobStats) {
+	fmt.Printf("cpu: %s\n", stats.GetCpuUsage().AsDuration())
+	if !stats.GetFinal() {
+		fmt.Printf("  user: %s system: %s throttled: %s in %d periods\n", stats.GetCpuUser().AsDuration(),
+			stats.GetCpuSystem().AsDuration(), stats.GetCpuThrottled().AsDuration(), stats.GetCpuThrottledPeriods())
+		fmt.Printf("memory: %s peak: %s\n", utils.FormatBytes(stats.GetMemoryCurrent()), utils.FormatBytes(stats.GetMemoryPeak()))
+		keys := make([]string, 0, len(stats.GetMemoryStat()))
+		for key := range stats.GetMemoryStat() {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Printf("  %s: %d\n", key, stats.GetMemoryStat()[key])
+		}
+	} else {
+		fmt.Printf("memory peak: %s\n", utils.FormatBytes(stats.GetMemoryPeak()))
+	}
+	fmt.Printf("io read: %s written: %s\n", utils.FormatBytes(stats.GetReadBytes()), utils.FormatBytes(stats.GetWrittenBytes()))
+	for _, io := range stats.GetIo() {
+		fmt.Printf("  %s read: %s written: %s read ios: %d write ios: %d\n", io.GetDevice(),
+			utils.FormatBytes(io.GetReadBytes()), utils.FormatBytes(io.GetWrittenBytes()), io.GetReadIos(), io.GetWriteIos())
+	}
+	if !stats.GetFinal() {
+		fmt.Printf("pids: %d\n", stats.GetPidsCurrent())
+	}
+}
+
+var clientListCommand = &cli.Command{
+	Name: "list",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "status",
+			Usage: "only list jobs with this status",
+		},
+		&cli.StringFlag{
+			Name:  "owner",
+			Usage: "only list jobs started by this user",
+		},
+		&cli.StringFlag{
+			Name:  "started-after",
+			Usage: "only list jobs started after this RFC3339 time",
+		},
+		&cli.StringFlag{
+			Name:  "started-before",
+			Usage: "only list jobs started before this RFC3339 time",
+		},
+		&cli.IntFlag{
+			Name:  "page-size",
+			Usage: "number of jobs to fetch per request",
+		},
+		&cli.StringFlag{
+			Name:  "page-token",
+			Usage: "token returned by a previous list to continue from",
+		},
+		&cli.BoolFlag{
+			Name:  "all",
+			Usage: "keep fetching until every page has been listed",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		ctx := c.Context
+		req := &proto.ListRequest{
+			Status:    c.String("status"),
+			Owner:     c.String("owner"),
+			PageSize:  int32(c.Int("page-size")),
+			PageToken: c.String("page-token"),
+		}
+		for flag, field := range map[string]**timestamppb.Timestamp{
+			"started-after":  &req.StartedAfter,
+			"started-before": &req.StartedBefore,
+		} {
+			if !c.IsSet(flag) {
+				continue
+			}
+			t, err := time.Parse(time.RFC3339, c.String(flag))
+			if err != nil {
+				return fmt.Errorf("invalid --%s: %w", flag, err)
+			}
+			*field = timestamppb.New(t)
+		}
+
+		clientConf := GetDefaultConfigFromCLI(c)
+		client, err := clientConf.Build(ctx)
+		if err != nil {
+			return fmt.Errorf("Build: %w", err)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tSTATUS\tOWNER\tSTARTED\tENDED\tCMD")
+		for {
+			resp, err := client.List(ctx, req)
+			if err != nil {
+				return err
+			}
+			for _, job := range resp.GetJobs() {
+				fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\n",
+					job.GetId(),
+					formatStatus(job),
+					job.GetOwner(),
+					formatTimestamp(job.GetStartedAt()),
+					formatTimestamp(job.GetEndedAt()),
+					strings.Join(job.GetCmd(), " "),
+				)
+			}
+			req.PageToken = resp.GetNextPageToken()
+			if req.PageToken == "" || !c.Bool("all") {
+				break
+			}
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+		if req.PageToken != "" {
+			fmt.Printf("next page token: %s\n", req.PageToken)
+		}
+		return nil
+	},
+}
+
+// formatStatus shows the queue position of queued jobs and the attempt of retried jobs next to their status
+func formatStatus(job *proto.Job) string {
+	status := job.GetStatus()
+	if job.GetQueuePosition() > 0 {
+		status += fmt.Sprintf(" (%d)", job.GetQueuePosition())
+	}
+	if job.GetMaxAttempts() > 1 {
+		status += fmt.Sprintf(" [%d/%d]", job.GetAttempt(), job.GetMaxAttempts())
+	}
+	return status
+}
+
+func formatTimestamp(ts *timestamppb.Timestamp) string {
+	if ts == nil {
+		return "-"
+	}
+	return ts.AsTime().Local().Format(time.RFC3339)
+}
+
 var clientStartCommand = &cli.Command{
-	Name: "start",
+	Name:  "start",
+	Flags: startFlags(),
 	Action: func(c *cli.Context) error {
 		ctx := c.Context
 		clientConf := GetDefaultConfigFromCLI(c)
@@ -123,10 +370,11 @@ var clientStartCommand = &cli.Command{
 		if err != nil {
 			return fmt.Errorf("Build: %w", err)
 		}
-		if len(c.Args().Slice()) == 0 {
-			return fmt.Errorf("missing cmd")
+		req, err := startRequest(c)
+		if err != nil {
+			return err
 		}
-		job, err := client.Start(ctx, c.Args().Slice())
+		job, err := client.Start(ctx, req)
 		if err != nil {
 			return err
 		}
@@ -135,6 +383,348 @@ var clientStartCommand = &cli.Command{
 	},
 }
 
+// startFlags returns the flags describing a job, shared by the commands that start jobs
+func startFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:    "stdin",
+			Aliases: []string{"i"},
+			Usage:   "keep stdin open so input can be sent with attach",
+		},
+		&cli.BoolFlag{
+			Name:    "tty",
+			Aliases: []string{"t"},
+			Usage:   "run the job under a pseudo-terminal, implies --stdin",
+		},
+		&cli.IntFlag{
+			Name:  "cpu-weight",
+			Usage: "relative cpu weight of the job, the server default is used when unset",
+		},
+		&cli.StringFlag{
+			Name:  "memory",
+			Usage: "maximum memory of the job in bytes or with a unit like 512M or 1G, the server default is used when unset",
+		},
+		&cli.Int64Flag{
+			Name:  "io",
+			Usage: "maximum write iops of the job, the server default is used when unset",
+		},
+		&cli.StringSliceFlag{
+			Name:    "env",
+			Aliases: []string{"e"},
+			Usage:   "set an environment variable of the job as KEY=VALUE, KEY alone passes on the local value. can be repeated",
+		},
+		&cli.StringFlag{
+			Name:  "workdir",
+			Usage: "absolute working directory of the job",
+		},
+		&cli.StringFlag{
+			Name:    "user",
+			Aliases: []string{"u"},
+			Usage:   "run the job as user or user:group, by name or numeric id",
+		},
+		&cli.StringFlag{
+			Name:  "output-limit",
+			Usage: "bytes of stdout and of stderr kept, with a unit like 512K or 1G, the server default is used when unset",
+		},
+		&cli.IntFlag{
+			Name:  "priority",
+			Usage: "queued jobs with a higher priority start first, within the range allowed by your role",
+		},
+		&cli.BoolFlag{
+			Name:  "preempt",
+			Usage: "when the job is queued, stop the running job with the lowest priority below its priority",
+		},
+		&cli.BoolFlag{
+			Name:  "requeue-on-preempt",
+			Usage: "queue the job again if it is preempted instead of ending it",
+		},
+		&cli.StringFlag{
+			Name:  "output-policy",
+			Usage: "what happens once the output exceeds its limit: ring drops the oldest output, stop discards new output, kill kills the job",
+		},
+		&cli.IntFlag{
+			Name:  "max-attempts",
+			Usage: "run the job again when it fails, up to this many times in total",
+		},
+		&cli.IntSliceFlag{
+			Name:  "retry-exit-code",
+			Usage: "only retry the job when it exits with this code, can be repeated. every failure is retried unless this or --retry-signal is set",
+		},
+		&cli.StringSliceFlag{
+			Name:  "retry-signal",
+			Usage: "only retry the job when it is stopped by this signal, can be repeated",
+		},
+		&cli.DurationFlag{
+			Name:  "retry-backoff",
+			Usage: "delay before the second attempt, doubling for each attempt after, the server defaults to 1s",
+		},
+		&cli.DurationFlag{
+			Name:  "retry-max-backoff",
+			Usage: "longest delay between attempts, the server defaults to 5m",
+		},
+		&cli.DurationFlag{
+			Name:  "timeout",
+			Usage: "stop the job once it has run this long, within the server maximum. the server default is used when unset",
+		},
+	}
+}
+
+// startRequest builds the request starting the job described by the start flags and the arguments of c
+func startRequest(c *cli.Context) (*proto.StartRequest, error) {
+	if len(c.Args().Slice()) == 0 {
+		return nil, fmt.Errorf("missing cmd")
+	}
+	var err error
+	var memory int64
+	if c.IsSet("memory") {
+		memory, err = utils.ParseBytes(c.String("memory"))
+		if err != nil {
+			return nil, fmt.Errorf("memory: %w", err)
+		}
+	}
+	var outputLimit int64
+	if c.IsSet("output-limit") {
+		outputLimit, err = utils.ParseBytes(c.String("output-limit"))
+		if err != nil {
+			return nil, fmt.Errorf("output-limit: %w", err)
+		}
+	}
+	outputPolicy, err := parseOutputPolicy(c.String("output-policy"))
+	if err != nil {
+		return nil, err
+	}
+	req := &proto.StartRequest{
+		Cmd:       c.Args().Slice(),
+		CpuWeight: int32(c.Int("cpu-weight")),
+		MaxMemUse: memory,
+		MaxDiskIo: c.Int64("io"),
+		Stdin:     c.Bool("stdin"),
+		Tty:       c.Bool("tty"),
+		Env:       expandEnv(c.StringSlice("env")),
+		Workdir:   c.String("workdir"),
+		User:      c.String("user"),
+
+		OutputLimit:  outputLimit,
+		OutputPolicy: outputPolicy,
+
+		Priority:         int32(c.Int("priority")),
+		Preempt:          c.Bool("preempt"),
+		RequeueOnPreempt: c.Bool("requeue-on-preempt"),
+
+		Retry: retryPolicy(c),
+	}
+	if c.IsSet("timeout") {
+		req.Timeout = durationpb.New(c.Duration("timeout"))
+	}
+	return req, nil
+}
+
+// retryPolicy returns the retry policy described by the start flags, nil if the job is not retried
+func retryPolicy(c *cli.Context) *proto.RetryPolicy {
+	if !c.IsSet("max-attempts") {
+		return nil
+	}
+	policy := &proto.RetryPolicy{
+		MaxAttempts: int32(c.Int("max-attempts")),
+		Signals:     c.StringSlice("retry-signal"),
+	}
+	for _, code := range c.IntSlice("retry-exit-code") {
+		policy.ExitCodes = append(policy.ExitCodes, int32(code))
+	}
+	if c.IsSet("retry-backoff") {
+		policy.InitialBackoff = durationpb.New(c.Duration("retry-backoff"))
+	}
+	if c.IsSet("retry-max-backoff") {
+		policy.MaxBackoff = durationpb.New(c.Duration("retry-max-backoff"))
+	}
+	return policy
+}
+
+// parseOutputPolicy parses ring, stop or kill, an empty policy is the server default
+func parseOutputPolicy(s string) (proto.OutputPolicy, error) {
+	if s == "" {
+		return proto.OutputPolicy_OUTPUT_POLICY_DEFAULT, nil
+	}
+	policy, ok := proto.OutputPolicy_value["OUTPUT_POLICY_"+strings.ToUpper(s)]
+	if !ok || policy == int32(proto.OutputPolicy_OUTPUT_POLICY_DEFAULT) {
+		return 0, fmt.Errorf("unknown output policy %q, must be ring, stop or kill", s)
+	}
+	return proto.OutputPolicy(policy), nil
+}
+
+func outputPolicyName(policy proto.OutputPolicy) string {
+	return strings.ToLower(strings.TrimPrefix(policy.String(), "OUTPUT_POLICY_"))
+}
+
+// expandEnv replaces each KEY without a value with KEY=VALUE from the local environment.
+// Keys that are not set locally are dropped.
+func expandEnv(env []string) []string {
+	var expanded []string
+	for _, kv := range env {
+		if strings.Contains(kv, "=") {
+			expanded = append(expanded, kv)
+			continue
+		}
+		if value, ok := os.LookupEnv(kv); ok {
+			expanded = append(expanded, kv+"="+value)
+		}
+	}
+	return expanded
+}
+
+var clientEventsCommand = &cli.Command{
+	Name:  "events",
+	Usage: "print job lifecycle events as they happen",
+	Flags: []cli.Flag{
+		&cli.IntFlag{
+			Name:  "id",
+			Usage: "only print the events of this job",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		ctx, cancel := signal.NotifyContext(c.Context, os.Interrupt)
+		defer cancel()
+		clientConf := GetDefaultConfigFromCLI(c)
+		client, err := clientConf.Build(ctx)
+		if err != nil {
+			return fmt.Errorf("Build: %w", err)
+		}
+		err = client.Watch(ctx, int32(c.Int("id")))
+		if ctx.Err() != nil {
+			return nil
+		}
+		return err
+	},
+}
+
+var clientWaitCommand = &cli.Command{
+	Name:  "wait",
+	Usage: "wait for a job to end and exit with its exit code, or 128 plus the signal number if it was stopped by a signal",
+	Flags: []cli.Flag{
+		&cli.IntFlag{
+			Name:     "id",
+			Required: true,
+		},
+		&cli.DurationFlag{
+			Name:  "timeout",
+			Usage: "give up waiting after this long, waits until the job ends when unset",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		ctx := c.Context
+		clientConf := GetDefaultConfigFromCLI(c)
+		client, err := clientConf.Build(ctx)
+		if err != nil {
+			return fmt.Errorf("Build: %w", err)
+		}
+		resp, err := client.Wait(ctx, int32(c.Int("id")), c.Duration("timeout"))
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "id: %d status: %s exit code: %d signal: %s started: %s ended: %s duration: %s\n",
+			resp.GetId(),
+			resp.GetStatus(),
+			resp.GetExitCode(),
+			resp.GetSignal(),
+			formatTimestamp(resp.GetStartedAt()),
+			formatTimestamp(resp.GetEndedAt()),
+			resp.GetDuration().AsDuration(),
+		)
+
+		if code := jobs.ExitCode(resp); code != 0 {
+			return cli.Exit("", code)
+		}
+		return nil
+	},
+}
+
+var clientKillCommand = &cli.Command{
+	Name:  "kill",
+	Usage: "send a signal to a running job",
+	Flags: []cli.Flag{
+		&cli.IntFlag{
+			Name:     "id",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:    "signal",
+			Aliases: []string{"s"},
+			Usage:   "signal by name or number",
+			Value:   "SIGTERM",
+		},
+		&cli.BoolFlag{
+			Name:  "all",
+			Usage: "send the signal to every process of the job instead of only its command",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		ctx := c.Context
+		clientConf := GetDefaultConfigFromCLI(c)
+		client, err := clientConf.Build(ctx)
+		if err != nil {
+			return fmt.Errorf("Build: %w", err)
+		}
+		return client.Signal(ctx, &proto.SignalRequest{
+			Id:           int32(c.Int("id")),
+			Signal:       c.String("signal"),
+			AllProcesses: c.Bool("all"),
+		})
+	},
+}
+
+var clientPriorityCommand = &cli.Command{
+	Name:  "priority",
+	Usage: "change the priority of a job that has not ended",
+	Flags: []cli.Flag{
+		&cli.IntFlag{
+			Name:     "id",
+			Required: true,
+		},
+		&cli.IntFlag{
+			Name:     "priority",
+			Required: true,
+		},
+	},
+	Action: func(c *cli.Context) error {
+		ctx := c.Context
+		clientConf := GetDefaultConfigFromCLI(c)
+		client, err := clientConf.Build(ctx)
+		if err != nil {
+			return fmt.Errorf("Build: %w", err)
+		}
+		job, err := client.UpdatePriority(ctx, int32(c.Int("id")), int32(c.Int("priority")))
+		if err != nil {
+			return err
+		}
+		fmt.Printf("job %d priority: %d status: %s\n", job.GetId(), job.GetPriority(), formatStatus(job))
+		return nil
+	},
+}
+
+var clientDeleteCommand = &cli.Command{
+	Name:  "delete",
+	Usage: "remove a job that has ended along with its output",
+	Flags: []cli.Flag{
+		&cli.IntFlag{
+			Name:     "id",
+			Required: true,
+		},
+		&cli.BoolFlag{
+			Name:  "keep-summary",
+			Usage: "remove only the output and keep how the job ran",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		ctx := c.Context
+		clientConf := GetDefaultConfigFromCLI(c)
+		client, err := clientConf.Build(ctx)
+		if err != nil {
+			return fmt.Errorf("Build: %w", err)
+		}
+		return client.Delete(ctx, int32(c.Int("id")), c.Bool("keep-summary"))
+	},
+}
+
 var clientStopCommand = &cli.Command{
 	Name: "stop",
 	Flags: []cli.Flag{
@@ -142,6 +732,15 @@ var clientStopCommand = &cli.Command{
 			Name:     "id",
 			Required: true,
 		},
+		&cli.StringFlag{
+			Name:    "signal",
+			Aliases: []string{"s"},
+			Usage:   "signal to send first by name or number, the server defaults to SIGTERM",
+		},
+		&cli.DurationFlag{
+			Name:  "grace-period",
+			Usage: "time the job has to exit after the signal before it is killed, the server defaults to 10s",
+		},
 	},
 	Action: func(c *cli.Context) error {
 		ctx := c.Context
@@ -150,11 +749,18 @@ var clientStopCommand = &cli.Command{
 		if err != nil {
 			return fmt.Errorf("Build: %w", err)
 		}
-		_, err = client.Stop(ctx, int32(c.Int("id")))
+		req := proto.StopRequest{
+			Id:     int32(c.Int("id")),
+			Signal: c.String("signal"),
+		}
+		if c.IsSet("grace-period") {
+			req.GracePeriod = durationpb.New(c.Duration("grace-period"))
+		}
+		resp, err := client.Stop(ctx, &req)
 		if err != nil {
 			return err
 		}
-		fmt.Printf("job stopped")
+		fmt.Printf("job stopped, status: %s exit code: %d signal: %s\n", resp.GetStatus(), resp.GetExitCode(), resp.GetSignal())
 		return nil
 	},
 }
@@ -166,6 +772,32 @@ var clientStreamCommand = &cli.Command{
 			Name:     "id",
 			Required: true,
 		},
+		&cli.Int64Flag{
+			Name:  "offset",
+			Usage: "byte offset in the output to start streaming from",
+		},
+		&cli.Int64Flag{
+			Name:  "tail-bytes",
+			Usage: "only stream the last N bytes of the output",
+		},
+		&cli.IntFlag{
+			Name:    "tail-lines",
+			Aliases: []string{"n"},
+			Usage:   "only stream the last N lines of the output",
+		},
+		&cli.BoolFlag{
+			Name:  "no-follow",
+			Usage: "exit once the current output has been printed instead of waiting for more",
+		},
+		&cli.StringFlag{
+			Name:  "source",
+			Usage: "output to stream: stdout, stderr or both",
+			Value: "stdout",
+		},
+		&cli.IntFlag{
+			Name:  "attempt",
+			Usage: "attempt of a retried job to stream, defaults to the current attempt",
+		},
 	},
 	Action: func(c *cli.Context) error {
 		ctx := c.Context
@@ -174,9 +806,411 @@ var clientStreamCommand = &cli.Command{
 		if err != nil {
 			return fmt.Errorf("Build: %w", err)
 		}
-		if err := client.Stream(ctx, int32(c.Int("id"))); err != nil {
+		source, ok := proto.Source_value["SOURCE_"+strings.ToUpper(c.String("source"))]
+		if !ok {
+			return fmt.Errorf("invalid --source %q", c.String("source"))
+		}
+		req := &proto.StreamRequest{
+			Id:        int32(c.Int("id")),
+			Offset:    c.Int64("offset"),
+			TailBytes: c.Int64("tail-bytes"),
+			TailLines: int32(c.Int("tail-lines")),
+			NoFollow:  c.Bool("no-follow"),
+			Source:    proto.Source(source),
+			Attempt:   int32(c.Int("attempt")),
+		}
+		if err := client.Stream(ctx, req); err != nil {
 			return fmt.Errorf("Stream: %w", err)
 		}
 		return nil
 	},
 }
+
+var clientAttachCommand = &cli.Command{
+	Name:  "attach",
+	Usage: "send stdin to a job started with --stdin and print its output",
+	Flags: []cli.Flag{
+		&cli.IntFlag{
+			Name:     "id",
+			Required: true,
+		},
+	},
+	Action: func(c *cli.Context) error {
+		ctx := c.Context
+		clientConf := GetDefaultConfigFromCLI(c)
+		client, err := clientConf.Build(ctx)
+		if err != nil {
+			return fmt.Errorf("Build: %w", err)
+		}
+		jobID := int32(c.Int("id"))
+		job, err := client.Get(ctx, jobID)
+		if err != nil {
+			return err
+		}
+
+		var sizes chan *proto.TerminalSize
+		if job.GetTty() && ptyz.IsTerminal(os.Stdin) {
+			// the remote terminal handles echo and line editing, so keystrokes are passed through untouched
+			state, err := ptyz.MakeRaw(os.Stdin)
+			if err != nil {
+				return fmt.Errorf("MakeRaw: %w", err)
+			}
+			defer ptyz.Restore(os.Stdin, state)
+
+			sizes = make(chan *proto.TerminalSize, 1)
+			winch := make(chan os.Signal, 1)
+			signal.Notify(winch, syscall.SIGWINCH)
+			defer signal.Stop(winch)
+			winch <- syscall.SIGWINCH // send the initial size
+			go func() {
+				for range winch {
+					rows, cols, err := ptyz.Getsize(os.Stdin)
+					if err != nil {
+						continue
+					}
+					// only the latest size matters, replace any size that has not been sent yet
+					select {
+					case <-sizes:
+					default:
+					}
+					sizes <- &proto.TerminalSize{Rows: uint32(rows), Cols: uint32(cols)}
+				}
+			}()
+		}
+
+		if err := client.Attach(ctx, jobID, os.Stdin, sizes); err != nil {
+			return fmt.Errorf("Attach: %w", err)
+		}
+		return nil
+	},
+}
+
+var clientScheduleCommand = &cli.Command{
+	Name:  "schedule",
+	Usage: "manage schedules that start a job every time a cron expression matches",
+	Subcommands: []*cli.Command{
+		{
+			Name:      "create",
+			Usage:     "start the job on a recurring basis, it takes the flags of start",
+			ArgsUsage: "cmd [args...]",
+			Flags: append(startFlags(),
+				&cli.StringFlag{
+					Name:     "cron",
+					Usage:    "cron expression of minute, hour, day of month, month and day of week, or a macro like @daily",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:  "timezone",
+					Usage: "IANA time zone the expression is evaluated in, defaults to UTC",
+				},
+				&cli.StringFlag{
+					Name:  "concurrency",
+					Usage: "what happens when the previous job is still running: allow starts another, forbid skips the run, replace stops it",
+					Value: "allow",
+				},
+			),
+			Action: func(c *cli.Context) error {
+				ctx := c.Context
+				concurrency, ok := proto.ConcurrencyPolicy_value["CONCURRENCY_POLICY_"+strings.ToUpper(c.String("concurrency"))]
+				if !ok {
+					return fmt.Errorf("unknown concurrency policy %q, must be allow, forbid or replace", c.String("concurrency"))
+				}
+				job, err := startRequest(c)
+				if err != nil {
+					return err
+				}
+				clientConf := GetDefaultConfigFromCLI(c)
+				client, err := clientConf.Build(ctx)
+				if err != nil {
+					return fmt.Errorf("Build: %w", err)
+				}
+				schedule, err := client.CreateSchedule(ctx, &proto.CreateScheduleRequest{
+					Cron:        c.String("cron"),
+					Timezone:    c.String("timezone"),
+					Job:         job,
+					Concurrency: proto.ConcurrencyPolicy(concurrency),
+				})
+				if err != nil {
+					return err
+				}
+				fmt.Printf("schedule id: %d next run: %s\n", schedule.GetId(), formatTimestamp(schedule.GetNextRun()))
+				return nil
+			},
+		},
+		{
+			Name:  "list",
+			Usage: "list schedules along with the jobs they started last",
+			Action: func(c *cli.Context) error {
+				ctx := c.Context
+				clientConf := GetDefaultConfigFromCLI(c)
+				client, err := clientConf.Build(ctx)
+				if err != nil {
+					return fmt.Errorf("Build: %w", err)
+				}
+				schedules, err := client.ListSchedules(ctx)
+				if err != nil {
+					return err
+				}
+				w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+				fmt.Fprintln(w, "ID\tOWNER\tCRON\tTIMEZONE\tCONCURRENCY\tNEXT RUN\tJOBS\tCMD")
+				for _, schedule := range schedules {
+					nextRun := formatTimestamp(schedule.GetNextRun())
+					if schedule.GetPaused() {
+						nextRun = "paused"
+					}
+					fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+						schedule.GetId(),
+						schedule.GetOwner(),
+						schedule.GetCron(),
+						schedule.GetTimezone(),
+						strings.ToLower(strings.TrimPrefix(schedule.GetConcurrency().String(), "CONCURRENCY_POLICY_")),
+						nextRun,
+						formatHistory(schedule.GetHistory()),
+						strings.Join(schedule.GetCmd(), " "),
+					)
+				}
+				return w.Flush()
+			},
+		},
+		{
+			Name:  "delete",
+			Usage: "remove a schedule, the jobs it started are kept",
+			Flags: []cli.Flag{
+				&cli.IntFlag{
+					Name:     "id",
+					Required: true,
+				},
+			},
+			Action: func(c *cli.Context) error {
+				ctx := c.Context
+				clientConf := GetDefaultConfigFromCLI(c)
+				client, err := clientConf.Build(ctx)
+				if err != nil {
+					return fmt.Errorf("Build: %w", err)
+				}
+				return client.DeleteSchedule(ctx, int32(c.Int("id")))
+			},
+		},
+		{
+			Name:  "pause",
+			Usage: "stop a schedule from starting jobs until it is resumed",
+			Flags: []cli.Flag{
+				&cli.IntFlag{
+					Name:     "id",
+					Required: true,
+				},
+			},
+			Action: func(c *cli.Context) error {
+				return pauseSchedule(c, false)
+			},
+		},
+		{
+			Name:  "resume",
+			Usage: "resume a paused schedule, runs missed while it was paused are skipped",
+			Flags: []cli.Flag{
+				&cli.IntFlag{
+					Name:     "id",
+					Required: true,
+				},
+			},
+			Action: func(c *cli.Context) error {
+				return pauseSchedule(c, true)
+			},
+		},
+	},
+}
+
+func pauseSchedule(c *cli.Context, resume bool) error {
+	ctx := c.Context
+	clientConf := GetDefaultConfigFromCLI(c)
+	client, err := clientConf.Build(ctx)
+	if err != nil {
+		return fmt.Errorf("Build: %w", err)
+	}
+	schedule, err := client.PauseSchedule(ctx, int32(c.Int("id")), resume)
+	if err != nil {
+		return err
+	}
+	if schedule.GetPaused() {
+		fmt.Printf("schedule %d paused\n", schedule.GetId())
+	} else {
+		fmt.Printf("schedule %d next run: %s\n", schedule.GetId(), formatTimestamp(schedule.GetNextRun()))
+	}
+	return nil
+}
+
+// maxListedHistory is the number of jobs started last shown for each schedule
+const maxListedHistory = 5
+
+// formatHistory lists the ids of the jobs started last by a schedule
+func formatHistory(history []int32) string {
+	if len(history) == 0 {
+		return "-"
+	}
+	var ids []string
+	if len(history) > maxListedHistory {
+		ids = append(ids, "...")
+		history = history[len(history)-maxListedHistory:]
+	}
+	for _, id := range history {
+		ids = append(ids, fmt.Sprint(id))
+	}
+	return strings.Join(ids, ",")
+}
+
+var clientWorkflowCommand = &cli.Command{
+	Name:  "workflow",
+	Usage: "run graphs of jobs where each job starts once the jobs it depends on have ended",
+	Subcommands: []*cli.Command{
+		{
+			Name: "start",
+			Usage: "start the workflow described in a json file, for example " +
+				`{"nodes": [{"name": "build", "job": {"cmd": ["make"]}}, ` +
+				`{"name": "test", "job": {"cmd": ["make", "test"]}, "depends_on": ["build"]}, ` +
+				`{"name": "notify", "job": {"cmd": ["echo", "failed"]}, "depends_on": ["test"], "condition": "CONDITION_ON_FAILURE"}]}`,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "file",
+					Aliases:  []string{"f"},
+					Usage:    "json file of the workflow, - reads it from stdin",
+					Required: true,
+				},
+			},
+			Action: func(c *cli.Context) error {
+				ctx := c.Context
+				var data []byte
+				var err error
+				if c.String("file") == "-" {
+					data, err = io.ReadAll(os.Stdin)
+				} else {
+					data, err = os.ReadFile(c.String("file"))
+				}
+				if err != nil {
+					return fmt.Errorf("read workflow: %w", err)
+				}
+				var req proto.StartWorkflowRequest
+				if err := protojson.Unmarshal(data, &req); err != nil {
+					return fmt.Errorf("parse workflow: %w", err)
+				}
+				for _, node := range req.GetNodes() {
+					if node.GetJob() != nil {
+						node.Job.Env = expandEnv(node.Job.Env)
+					}
+				}
+				clientConf := GetDefaultConfigFromCLI(c)
+				client, err := clientConf.Build(ctx)
+				if err != nil {
+					return fmt.Errorf("Build: %w", err)
+				}
+				workflow, err := client.StartWorkflow(ctx, &req)
+				if err != nil {
+					return err
+				}
+				fmt.Printf("workflow id: %d\n", workflow.GetId())
+				return nil
+			},
+		},
+		{
+			Name:  "get",
+			Usage: "print the status of a workflow and of each of its nodes",
+			Flags: []cli.Flag{
+				&cli.IntFlag{
+					Name:     "id",
+					Required: true,
+				},
+			},
+			Action: func(c *cli.Context) error {
+				ctx := c.Context
+				clientConf := GetDefaultConfigFromCLI(c)
+				client, err := clientConf.Build(ctx)
+				if err != nil {
+					return fmt.Errorf("Build: %w", err)
+				}
+				workflow, err := client.GetWorkflow(ctx, int32(c.Int("id")))
+				if err != nil {
+					return err
+				}
+				printWorkflow(workflow)
+				return nil
+			},
+		},
+		{
+			Name:  "list",
+			Usage: "list workflows",
+			Action: func(c *cli.Context) error {
+				ctx := c.Context
+				clientConf := GetDefaultConfigFromCLI(c)
+				client, err := clientConf.Build(ctx)
+				if err != nil {
+					return fmt.Errorf("Build: %w", err)
+				}
+				workflows, err := client.ListWorkflows(ctx)
+				if err != nil {
+					return err
+				}
+				w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+				fmt.Fprintln(w, "ID\tSTATUS\tOWNER\tCREATED\tENDED\tNODES")
+				for _, workflow := range workflows {
+					fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%d\n",
+						workflow.GetId(),
+						workflow.GetStatus(),
+						workflow.GetOwner(),
+						formatTimestamp(workflow.GetCreatedAt()),
+						formatTimestamp(workflow.GetEndedAt()),
+						len(workflow.GetNodes()),
+					)
+				}
+				return w.Flush()
+			},
+		},
+		{
+			Name:  "stop",
+			Usage: "stop the running jobs of a workflow and cancel the jobs that have not started",
+			Flags: []cli.Flag{
+				&cli.IntFlag{
+					Name:     "id",
+					Required: true,
+				},
+			},
+			Action: func(c *cli.Context) error {
+				ctx := c.Context
+				clientConf := GetDefaultConfigFromCLI(c)
+				client, err := clientConf.Build(ctx)
+				if err != nil {
+					return fmt.Errorf("Build: %w", err)
+				}
+				workflow, err := client.StopWorkflow(ctx, int32(c.Int("id")))
+				if err != nil {
+					return err
+				}
+				printWorkflow(workflow)
+				return nil
+			},
+		},
+	},
+}
+
+func printWorkflow(workflow *proto.Workflow) {
+	fmt.Printf("workflow %d status: %s owner: %s\n", workflow.GetId(), workflow.GetStatus(), workflow.GetOwner())
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "NODE\tSTATUS\tJOB\tDEPENDS ON\tCONDITION\tCMD")
+	for _, node := range workflow.GetNodes() {
+		job := "-"
+		if node.GetJobId() != 0 {
+			job = fmt.Sprint(node.GetJobId())
+		}
+		deps := "-"
+		if len(node.GetDependsOn()) > 0 {
+			deps = strings.Join(node.GetDependsOn(), ",")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			node.GetName(),
+			node.GetStatus(),
+			job,
+			deps,
+			strings.ToLower(strings.TrimPrefix(node.GetCondition().String(), "CONDITION_")),
+			strings.Join(node.GetCmd(), " "),
+		)
+	}
+	_ = w.Flush()
+}
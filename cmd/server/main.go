@@ -2,11 +2,14 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"net"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"syscall"
+	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -33,6 +36,48 @@ func main() {
 }
 
 func cmd() error {
+	limits := jobs.DefaultLimitConfig()
+	flag.IntVar(&limits.MinCPUWeight, "min-cpu-weight", limits.MinCPUWeight, "minimum cpu weight a job can request")
+	flag.IntVar(&limits.MaxCPUWeight, "max-cpu-weight", limits.MaxCPUWeight, "maximum cpu weight a job can request")
+	flag.IntVar(&limits.DefaultCPUWeight, "default-cpu-weight", limits.DefaultCPUWeight, "cpu weight of jobs that do not request one")
+	flag.Int64Var(&limits.MinMemory, "min-memory", limits.MinMemory, "minimum memory in bytes a job can request")
+	flag.Int64Var(&limits.MaxMemory, "max-memory", limits.MaxMemory, "maximum memory in bytes a job can request")
+	flag.Int64Var(&limits.DefaultMemory, "default-memory", limits.DefaultMemory, "memory in bytes of jobs that do not request it")
+	flag.Int64Var(&limits.MaxIO, "max-io", limits.MaxIO, "maximum write iops a job can request, 0 for no maximum")
+	flag.Int64Var(&limits.DefaultIO, "default-io", limits.DefaultIO, "write iops of jobs that do not request it, 0 for unlimited")
+	flag.IntVar(&limits.IODeviceMaj, "io-device-major", limits.IODeviceMaj, "major number of the block device io limits apply to")
+	flag.IntVar(&limits.IODeviceMin, "io-device-minor", limits.IODeviceMin, "minor number of the block device io limits apply to")
+	flag.Int64Var(&limits.MaxOutput, "max-output", limits.MaxOutput, "maximum bytes of stdout and of stderr each job can keep, 0 for no maximum")
+	flag.Int64Var(&limits.DefaultOutput, "default-output", limits.DefaultOutput, "bytes of stdout and of stderr kept for each job that does not request it, 0 for the maximum")
+	outputPolicy := flag.String("output-policy", string(limits.DefaultOutputPolicy), "what happens once the output of a job that does not request a policy exceeds its limit: ring, stop or kill")
+	flag.DurationVar(&limits.MaxTimeout, "max-timeout", limits.MaxTimeout, "maximum time a job can request to run before it is stopped, 0 for no maximum")
+	flag.DurationVar(&limits.DefaultTimeout, "default-timeout", limits.DefaultTimeout, "time jobs that do not request a timeout run before they are stopped, 0 for the maximum")
+	maxRunning := flag.Int("max-running", 0, "number of jobs that can run at once, further jobs are queued until one ends. 0 for no limit")
+	outputBudget := flag.Int64("output-budget", jobs.DefaultOutputBudget, "bytes of output all jobs together can keep, counting the output limit of jobs that have not ended. Jobs that do not fit are not started. 0 for no cap")
+	preemptGrace := flag.Duration("preempt-grace", 10*time.Second, "time a preempted job has to exit after SIGTERM before it is killed")
+	var retention jobs.RetentionPolicy
+	flag.DurationVar(&retention.MaxAge, "retention-max-age", 0, "remove jobs that ended longer ago than this, 0 keeps them")
+	flag.IntVar(&retention.MaxCount, "retention-max-count", 0, "remove the oldest jobs that ended once more than this many have ended, 0 keeps them")
+	flag.BoolVar(&retention.KeepSummary, "retention-keep-summary", false, "remove only the output of jobs the retention flags remove and keep how they ran")
+	defaultUser := flag.String("default-user", "nobody", "user or user:group, by name or numeric id, jobs run as unless they ask for one")
+	dataDir := flag.String("data-dir", "", "directory job history and output are kept in across restarts, such as /var/lib/job_runner. The environment of every job is kept there in plain text. Empty keeps them in memory only")
+	flag.Parse()
+	var err error
+	limits.DefaultOutputPolicy, err = jobs.ParseOutputPolicy(*outputPolicy)
+	if err != nil {
+		return fmt.Errorf("output-policy: %w", err)
+	}
+	if err := limits.Validate(); err != nil {
+		return fmt.Errorf("limits: %w", err)
+	}
+	if *outputBudget < 0 {
+		return fmt.Errorf("output-budget %d must not be negative", *outputBudget)
+	}
+	defaultCredential, err := jobs.LookupCredential(*defaultUser)
+	if err != nil {
+		return fmt.Errorf("default-user: %w", err)
+	}
+
 	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGKILL)
 	defer cancel()
 
@@ -51,8 +96,19 @@ func cmd() error {
 		grpc.ChainUnaryInterceptor(authn.UnaryServerInterceptor),
 		grpc.ChainStreamInterceptor(authn.StreamServerInterceptor),
 	)
-	jobService := jobs.NewService(ctx)
-	jobsAPI := jobs.NewJobs(jobService)
+	config := jobs.ServiceConfig{Retention: retention, MaxRunning: *maxRunning, PreemptGrace: *preemptGrace, OutputBudget: *outputBudget}
+	if *dataDir != "" {
+		config.Store, err = jobs.NewFileStore(filepath.Join(*dataDir, "jobs"))
+		if err != nil {
+			return fmt.Errorf("NewFileStore: %w", err)
+		}
+		config.OutputDir = filepath.Join(*dataDir, "output")
+	}
+	jobService, err := jobs.NewService(ctx, config)
+	if err != nil {
+		return fmt.Errorf("NewService: %w", err)
+	}
+	jobsAPI := jobs.NewJobs(jobService, limits, defaultCredential)
 	proto.RegisterJobServiceServer(server, jobsAPI)
 
 	listener, err := net.Listen("tcp", port)
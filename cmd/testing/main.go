@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"os/signal"
@@ -49,7 +50,7 @@ func run(args []string) error {
 	fmt.Printf("limits %+v\n", limits)
 	fmt.Printf("args: %v\n", args)
 
-	job := jobs.New(ctx, args[3:], limits)
+	job := jobs.New(ctx, jobs.Spec{Command: args[3:], Limits: limits})
 
 	var wg sync.WaitGroup
 
@@ -71,10 +72,21 @@ func run(args []string) error {
 
 	code, status := job.Result()
 	fmt.Printf("code: %d status: %s\n", code, status)
-	if job.Err != "" {
-		fmt.Printf("received err: %s\n", job.Err)
-	}
 	wg.Wait()
 
+	opts := jobs.StreamOptions{Source: jobs.SourceStderr}
+	if err := job.StreamAt(ctx, offsetlessWriter{os.Stderr}, opts); err != nil {
+		return fmt.Errorf("stream stderr: %w", err)
+	}
+
 	return nil
 }
+
+// offsetlessWriter writes chunks in the order they are received and ignores their offsets
+type offsetlessWriter struct {
+	io.Writer
+}
+
+func (w offsetlessWriter) WriteAt(p []byte, _ int64) (int, error) {
+	return w.Write(p)
+}
@@ -2,9 +2,12 @@ package main
 
 import (
 	"errors"
+	"flag"
 	"fmt"
 	"os"
 	"os/exec"
+	"os/signal"
+	"syscall"
 
 	"job_runner/pkg/cgroupz"
 )
@@ -14,9 +17,20 @@ import (
 // designated cgroup and then executes the target process
 //
 // the inputs are passed via os.Args
-// the first arg is the cgroup path
+// the optional flags come first, see below
+// the first arg after the flags is the cgroup path
 // the rest of the args is the command to execute
+// the stdin of this program is passed to the target process and
 // the stdout and stderr of the target process is piped back to this programs stdout and stderr
+// the environment and working directory of this program are inherited by the target process
+// this program exits with the exit code of the target process, or is killed by the same signal that killed it.
+// only SIGKILL, SIGHUP, SIGINT and SIGTERM can be raised again without restoring their default action, for other
+// signals this program exits with 128 plus the signal number like a shell
+//
+// flags:
+// -tty: stdin is a terminal, the target process is started in a new session with the terminal as its controlling terminal
+// -uid, -gid: the user and group the target process runs as, both must be set
+// -pid-fd: an inherited fd the pid of the target process is written to in decimal once it starts, the fd is then closed
 func main() {
 	code, err := run(os.Args)
 	if err != nil {
@@ -26,27 +40,110 @@ func main() {
 }
 
 func run(args []string) (int, error) {
-	if len(args) < 3 {
+	flags := flag.NewFlagSet(args[0], flag.ContinueOnError)
+	tty := flags.Bool("tty", false, "run the target process with stdin as its controlling terminal")
+	uid := flags.Int("uid", -1, "user id the target process runs as")
+	gid := flags.Int("gid", -1, "group id the target process runs as")
+	pidFd := flags.Int("pid-fd", -1, "inherited fd the pid of the target process is written to once it starts")
+	if err := flags.Parse(args[1:]); err != nil {
+		return -1, err
+	}
+	if (*uid < 0) != (*gid < 0) {
+		return -1, errors.New("-uid and -gid must be set together")
+	}
+	args = flags.Args()
+	if len(args) < 2 {
 		return -1, errors.New("not enough arguments")
 	}
 
-	cgroupPath := os.Args[1]
-	command := os.Args[2]
-	var cmdargs []string
-	if len(os.Args) >= 3 {
-		cmdargs = os.Args[3:]
+	var pidFile *os.File
+	if *pidFd >= 0 {
+		// the target process must not inherit the fd, the job sees it closed once this program has written to it
+		syscall.CloseOnExec(*pidFd)
+		pidFile = os.NewFile(uintptr(*pidFd), "pid")
+		defer pidFile.Close()
 	}
 
+	cgroupPath := args[0]
+	command := args[1]
+	cmdargs := args[2:]
+
 	if err := cgroupz.AddProcess(cgroupPath, os.Getpid()); err != nil {
 		return -1, fmt.Errorf("utility process: failed to add pid %d into cgroup at path %s", os.Getpid(), cgroupPath)
 	}
 	cmd := exec.Command(command, cmdargs...)
+	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Pdeathsig: syscall.SIGKILL,
+	}
+	if *tty {
+		// Ctty is the fd of the terminal in the target process, which is its stdin
+		cmd.SysProcAttr.Setsid = true
+		cmd.SysProcAttr.Setctty = true
+		cmd.SysProcAttr.Ctty = 0
+	}
+	if *uid >= 0 {
+		// an empty Groups drops the supplementary groups of this program
+		cmd.SysProcAttr.Credential = &syscall.Credential{
+			Uid:    uint32(*uid),
+			Gid:    uint32(*gid),
+			Groups: []uint32{},
+		}
+	}
+
+	// catch signals before starting so none sent in between terminates this program instead of reaching the target
+	signals := make(chan os.Signal, 16)
+	signal.Notify(signals)
+	defer signal.Stop(signals)
+	if err := cmd.Start(); err != nil {
+		return -1, err
+	}
+	go forwardSignals(signals, cmd.Process)
+	if pidFile != nil {
+		// the target process keeps running if the job can not read its pid, signals then reach it through this program
+		_, _ = fmt.Fprintf(pidFile, "%d", cmd.Process.Pid)
+		pidFile.Close()
+	}
+
+	if err := cmd.Wait(); err != nil {
 		if _, ok := err.(*exec.ExitError); !ok {
 			return -1, err
 		}
 	}
+	if status, ok := cmd.ProcessState.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+		raise(status.Signal())
+		// the signal can not terminate this program, fall back to the shell convention
+		return 128 + int(status.Signal()), nil
+	}
 	return cmd.ProcessState.ExitCode(), nil
 }
+
+// forwardSignals sends every signal received on signals to process for the lifetime of this program
+func forwardSignals(signals <-chan os.Signal, process *os.Process) {
+	for sig := range signals {
+		// SIGCHLD is about the target process itself and SIGURG is used by the go runtime for preemption
+		if sig == syscall.SIGCHLD || sig == syscall.SIGURG {
+			continue
+		}
+		_ = process.Signal(sig)
+	}
+}
+
+// raise terminates this program with sig so the job observes how the target process ended.
+// signal.Reset restores the go runtime's handling of sig rather than the default action, and the runtime only
+// terminates the program with the signal itself for SIGHUP, SIGINT and SIGTERM. It ignores other signals or dumps
+// goroutines and exits with code 2, so raise returns for them and the caller falls back to the shell convention.
+// the action of SIGKILL can not be changed, it always terminates the program.
+func raise(sig syscall.Signal) {
+	switch sig {
+	case syscall.SIGKILL, syscall.SIGHUP, syscall.SIGINT, syscall.SIGTERM:
+	default:
+		return
+	}
+	signal.Reset(sig)
+	_ = syscall.Kill(os.Getpid(), sig)
+	// delivery to this process is asynchronous, wait for it to terminate the program
+	select {}
+}
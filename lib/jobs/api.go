@@ -2,146 +2,1230 @@ package jobs
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"go.uber.org/multierr"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"job_runner/pkg/authn"
 	"job_runner/pkg/authorizer"
 	"job_runner/pkg/cgroupz"
+	"job_runner/pkg/jobs"
 	"job_runner/proto"
 )
 
 var _ proto.JobServiceServer = (*API)(nil)
 
+const (
+	// defaultStatsInterval is the time between the samples of StreamStats, unless the request sets it
+	defaultStatsInterval = time.Second
+	// minStatsInterval bounds how often StreamStats reads the cgroup of a job
+	minStatsInterval = 100 * time.Millisecond
+)
+
+// defaultStopGrace is how long a stopped job has to exit before it is killed, unless the request sets it
+const defaultStopGrace = 10 * time.Second
+
 type API struct {
-	lib   *Service
-	authz *authorizer.Authorizer
+	lib    *Service
+	authz  *authorizer.Authorizer
+	limits LimitConfig
+	// defaultUser is the user and group of jobs that do not ask for one, nil runs them as the server's user
+	defaultUser *jobs.Credential
 }
 
-// NewJobs returns a jobs api struct that implements the JobServiceServer grpc interface
-func NewJobs(lib *Service) *API {
+// NewJobs returns a jobs api struct that implements the JobServiceServer grpc interface.
+// Resource limits requested by clients are validated against limits. Jobs that do not ask for a user run as
+// defaultUser, which should be an unprivileged account.
+func NewJobs(lib *Service, limits LimitConfig, defaultUser *jobs.Credential) *API {
 	svc := API{
-		lib: lib,
+		lib:         lib,
+		authz:       authorizer.NewAuthorizer(),
+		limits:      limits,
+		defaultUser: defaultUser,
 	}
 	return &svc
 }
 
 func (a *API) Get(ctx context.Context, req *proto.GetRequest) (*proto.Job, error) {
-	userID, err := authn.FromMD(ctx)
+	subject, err := a.authenticate(ctx, authorizer.ActionGet)
 	if err != nil {
-		return nil, status.Error(codes.Unauthenticated, "missing id")
+		return nil, err
 	}
-	ok, err := a.authz.HasAccess(string(userID), authorizer.ActionGet)
+	record, err := a.authorizeJob(ctx, subject, authorizer.ActionGet, req.GetId())
 	if err != nil {
-		return nil, status.Error(codes.Unknown, "")
+		return nil, err
 	}
-	if !ok {
-		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+
+	return toProtoJob(record), nil
+}
+
+func (a *API) Stats(ctx context.Context, req *proto.StatsRequest) (*proto.JobStats, error) {
+	subject, err := a.authenticate(ctx, authorizer.ActionGet)
+	if err != nil {
+		return nil, err
 	}
-	cmd, err := a.lib.GetJob(ctx, req.GetId())
+	record, err := a.authorizeJob(ctx, subject, authorizer.ActionGet, req.GetId())
 	if err != nil {
 		return nil, err
 	}
 
-	job := proto.Job{
-		Id:     cmd.ID,
-		Status: string(cmd.Job.Status),
+	stats, err := record.Job.Stats()
+	if errors.Is(err, jobs.ErrNotRunning) {
+		state := record.Job.State()
+		if state.EndedAt.IsZero() {
+			// queued, it has not used anything yet
+			return &proto.JobStats{Id: record.ID}, nil
+		}
+		return toProtoUsage(record.ID, state.Usage), nil
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return toProtoStats(record.ID, stats), nil
+}
+
+func (a *API) StreamStats(req *proto.StreamStatsRequest, server proto.JobService_StreamStatsServer) error {
+	ctx := server.Context()
+	subject, err := a.authenticate(ctx, authorizer.ActionStream)
+	if err != nil {
+		return err
+	}
+	interval := defaultStatsInterval
+	if req.Interval != nil {
+		if err := req.GetInterval().CheckValid(); err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid interval: %v", err)
+		}
+		interval = req.GetInterval().AsDuration()
+		if interval < minStatsInterval {
+			return status.Errorf(codes.InvalidArgument, "interval must be at least %s", minStatsInterval)
+		}
+	}
+	record, err := a.authorizeJob(ctx, subject, authorizer.ActionStream, req.GetId())
+	if err != nil {
+		return err
 	}
 
-	return &job, nil
+	prev, err := record.Job.Stats()
+	if errors.Is(err, jobs.ErrNotRunning) {
+		return status.Errorf(codes.FailedPrecondition, "job %d is not running", record.ID)
+	}
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	prevTime := time.Now()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-record.Job.Done():
+			return nil
+		case <-ticker.C:
+		}
+		stats, err := record.Job.Stats()
+		if errors.Is(err, jobs.ErrNotRunning) {
+			return nil
+		}
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+		now := time.Now()
+		if err := server.Send(toProtoStatsSample(now, stats, stats.RatesSince(prev, now.Sub(prevTime)))); err != nil {
+			return err
+		}
+		prev, prevTime = stats, now
+	}
+}
+
+func (a *API) List(ctx context.Context, req *proto.ListRequest) (*proto.ListResponse, error) {
+	subject, err := a.authenticate(ctx, authorizer.ActionList)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := ListFilter{
+		Status:    jobs.Status(req.GetStatus()),
+		Owner:     req.GetOwner(),
+		PageSize:  int(req.GetPageSize()),
+		PageToken: req.GetPageToken(),
+	}
+	if req.StartedAfter != nil {
+		filter.StartedAfter = req.GetStartedAfter().AsTime()
+	}
+	if req.StartedBefore != nil {
+		filter.StartedBefore = req.GetStartedBefore().AsTime()
+	}
+	if ok, err := a.authz.Authorize(subject, authorizer.ActionList, ""); err != nil || !ok {
+		// the subject can only list their own jobs
+		if filter.Owner != "" && filter.Owner != subject {
+			return &proto.ListResponse{}, nil
+		}
+		filter.Owner = subject
+	}
+
+	records, next, err := a.lib.ListJobs(ctx, filter)
+	if errors.Is(err, ErrInvalidPageToken) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	resp := proto.ListResponse{NextPageToken: next}
+	for _, record := range records {
+		resp.Jobs = append(resp.Jobs, toProtoJob(record))
+	}
+	return &resp, nil
 }
 
 func (a *API) Start(ctx context.Context, req *proto.StartRequest) (*proto.Job, error) {
 	fmt.Println("Starting..")
-	userID, err := authn.FromMD(ctx)
+	subject, err := a.authenticate(ctx, authorizer.ActionStart)
+	if err != nil {
+		return nil, err
+	}
+
+	spec, opts, err := a.startSpec(subject, req)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := a.lib.StartJob(ctx, subject, spec, opts)
+	if err != nil {
+		if errors.Is(err, ErrOutputBudget) {
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		}
+		return nil, err
+	}
+
+	return toProtoJob(job), nil
+}
+
+// startSpec validates a StartRequest of subject and returns the job it describes
+func (a *API) startSpec(subject string, req *proto.StartRequest) (jobs.Spec, StartOptions, error) {
+	limits, err := a.limits.Resolve(int64(req.GetCpuWeight()), req.GetMaxMemUse(), req.GetMaxDiskIo())
 	if err != nil {
-		return nil, status.Error(codes.Unauthenticated, "missing id")
+		return jobs.Spec{}, StartOptions{}, status.Error(codes.InvalidArgument, err.Error())
 	}
-	ok, err := a.authz.HasAccess(string(userID), authorizer.ActionStart)
+	outputLimit, outputPolicy, err := a.limits.ResolveOutput(req.GetOutputLimit(), outputPolicies[req.GetOutputPolicy()])
 	if err != nil {
-		return nil, status.Error(codes.Unknown, "")
+		return jobs.Spec{}, StartOptions{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	for _, kv := range req.GetEnv() {
+		if key, _, ok := strings.Cut(kv, "="); !ok || key == "" {
+			return jobs.Spec{}, StartOptions{}, status.Errorf(codes.InvalidArgument, "env %q must be KEY=VALUE", kv)
+		}
+	}
+	if dir := req.GetWorkdir(); dir != "" && !filepath.IsAbs(dir) {
+		return jobs.Spec{}, StartOptions{}, status.Errorf(codes.InvalidArgument, "workdir %q must be an absolute path", dir)
+	}
+
+	spec := jobs.Spec{
+		Command: req.GetCmd(),
+		Limits:  limits,
+		Stdin:   req.GetStdin(),
+		TTY:     req.GetTty(),
+		Env:     req.GetEnv(),
+		Dir:     req.GetWorkdir(),
+
+		OutputLimit:  outputLimit,
+		OutputPolicy: outputPolicy,
+		Credential:   a.defaultUser,
+	}
+	if req.GetUser() != "" {
+		spec.Credential, err = LookupCredential(req.GetUser())
+		if err != nil {
+			return jobs.Spec{}, StartOptions{}, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if err := a.checkCredential(subject, spec.Credential); err != nil {
+			return jobs.Spec{}, StartOptions{}, err
+		}
+	}
+
+	retry, err := toRetryPolicy(req.GetRetry())
+	if err != nil {
+		return jobs.Spec{}, StartOptions{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	var timeout time.Duration
+	if req.Timeout != nil {
+		if err := req.GetTimeout().CheckValid(); err != nil {
+			return jobs.Spec{}, StartOptions{}, status.Errorf(codes.InvalidArgument, "invalid timeout: %v", err)
+		}
+		timeout = req.GetTimeout().AsDuration()
+	}
+	if timeout, err = a.limits.ResolveTimeout(timeout); err != nil {
+		return jobs.Spec{}, StartOptions{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	opts := StartOptions{
+		Priority:         req.GetPriority(),
+		Preempt:          req.GetPreempt(),
+		RequeueOnPreempt: req.GetRequeueOnPreempt(),
+		Retry:            retry,
+		Timeout:          timeout,
+	}
+	if err := a.checkPriority(subject, opts.Priority); err != nil {
+		return jobs.Spec{}, StartOptions{}, err
+	}
+
+	return spec, opts, nil
+}
+
+// toRetryPolicy validates the retry policy of a request, nil never retries
+func toRetryPolicy(req *proto.RetryPolicy) (RetryPolicy, error) {
+	if req == nil {
+		return RetryPolicy{}, nil
+	}
+	policy := RetryPolicy{MaxAttempts: int(req.GetMaxAttempts())}
+	for _, code := range req.GetExitCodes() {
+		policy.ExitCodes = append(policy.ExitCodes, int(code))
+	}
+	for _, name := range req.GetSignals() {
+		sig, err := jobs.ParseSignal(name)
+		if err != nil {
+			return RetryPolicy{}, err
+		}
+		policy.Signals = append(policy.Signals, sig)
+	}
+	if req.InitialBackoff != nil {
+		if err := req.GetInitialBackoff().CheckValid(); err != nil {
+			return RetryPolicy{}, fmt.Errorf("invalid initial backoff: %w", err)
+		}
+		policy.InitialBackoff = req.GetInitialBackoff().AsDuration()
+	}
+	if req.MaxBackoff != nil {
+		if err := req.GetMaxBackoff().CheckValid(); err != nil {
+			return RetryPolicy{}, fmt.Errorf("invalid max backoff: %w", err)
+		}
+		policy.MaxBackoff = req.GetMaxBackoff().AsDuration()
+	}
+	if err := policy.validate(); err != nil {
+		return RetryPolicy{}, err
+	}
+	return policy, nil
+}
+
+func (a *API) Stop(ctx context.Context, req *proto.StopRequest) (*proto.StopResponse, error) {
+	fmt.Println("Stopping..")
+
+	subject, err := a.authenticate(ctx, authorizer.ActionStop)
+	if err != nil {
+		return nil, err
+	}
+
+	sig := syscall.SIGTERM
+	if req.GetSignal() != "" {
+		sig, err = jobs.ParseSignal(req.GetSignal())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	grace := defaultStopGrace
+	if req.GracePeriod != nil {
+		if err := req.GetGracePeriod().CheckValid(); err != nil || req.GetGracePeriod().AsDuration() < 0 {
+			return nil, status.Error(codes.InvalidArgument, "grace period must be a non-negative duration")
+		}
+		grace = req.GetGracePeriod().AsDuration()
+	}
+
+	if _, err := a.authorizeJob(ctx, subject, authorizer.ActionStop, req.GetId()); err != nil {
+		return nil, err
+	}
+	record, err := a.lib.StopJob(ctx, req.GetId(), sig, grace)
+	if err != nil {
+		return nil, err
+	}
+	state := record.Job.State()
+	resp := proto.StopResponse{
+		ExitCode: int32(state.ExitCode),
+		Status:   string(state.Status),
+	}
+	if state.Signal != 0 {
+		resp.Signal = jobs.SignalName(state.Signal)
+	}
+	return &resp, nil
+}
+
+// Signal sends the requested signal to a running job
+func (a *API) Signal(ctx context.Context, req *proto.SignalRequest) (*proto.SignalResponse, error) {
+	subject, err := a.authenticate(ctx, authorizer.ActionSignal)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := jobs.ParseSignal(req.GetSignal())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if _, err := a.authorizeJob(ctx, subject, authorizer.ActionSignal, req.GetId()); err != nil {
+		return nil, err
+	}
+	err = a.lib.SignalJob(ctx, req.GetId(), sig, req.GetAllProcesses())
+	if errors.Is(err, jobs.ErrNotRunning) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &proto.SignalResponse{}, nil
+}
+
+// Wait blocks until the job has ended, the request's timeout passes or the client cancels
+func (a *API) Wait(ctx context.Context, req *proto.WaitRequest) (*proto.WaitResponse, error) {
+	subject, err := a.authenticate(ctx, authorizer.ActionGet)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := a.authorizeJob(ctx, subject, authorizer.ActionGet, req.GetId()); err != nil {
+		return nil, err
+	}
+
+	if req.Timeout != nil {
+		if err := req.GetTimeout().CheckValid(); err != nil || req.GetTimeout().AsDuration() < 0 {
+			return nil, status.Error(codes.InvalidArgument, "timeout must be a non-negative duration")
+		}
+		var cancel func()
+		ctx, cancel = context.WithTimeout(ctx, req.GetTimeout().AsDuration())
+		defer cancel()
+	}
+
+	record, err := a.lib.WaitJob(ctx, req.GetId())
+	if errors.Is(err, context.DeadlineExceeded) {
+		return nil, status.Error(codes.DeadlineExceeded, "job has not ended")
+	}
+	if errors.Is(err, context.Canceled) {
+		return nil, status.Error(codes.Canceled, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	state := record.Job.State()
+	resp := proto.WaitResponse{
+		Id:       record.ID,
+		Status:   string(state.Status),
+		ExitCode: int32(state.ExitCode),
+		EndedAt:  timestamppb.New(state.EndedAt),
+	}
+	if state.Signal != 0 {
+		resp.Signal = jobs.SignalName(state.Signal)
+	}
+	if !state.StartedAt.IsZero() {
+		resp.StartedAt = timestamppb.New(state.StartedAt)
+		resp.Duration = durationpb.New(state.EndedAt.Sub(state.StartedAt))
+	}
+	return &resp, nil
+}
+
+// UpdatePriority changes the priority of a job that has not ended
+func (a *API) UpdatePriority(ctx context.Context, req *proto.UpdatePriorityRequest) (*proto.Job, error) {
+	subject, err := a.authenticate(ctx, authorizer.ActionPriority)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.checkPriority(subject, req.GetPriority()); err != nil {
+		return nil, err
+	}
+	if _, err := a.authorizeJob(ctx, subject, authorizer.ActionPriority, req.GetId()); err != nil {
+		return nil, err
+	}
+	record, err := a.lib.UpdatePriority(ctx, req.GetId(), req.GetPriority())
+	if errors.Is(err, ErrJobEnded) {
+		return nil, status.Errorf(codes.FailedPrecondition, "job %d has ended", req.GetId())
+	}
+	if errors.Is(err, ErrJobNotFound) {
+		return nil, status.Errorf(codes.NotFound, "job %d not found", req.GetId())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toProtoJob(record), nil
+}
+
+// checkPriority returns PermissionDenied if the roles of subject do not allow priority
+func (a *API) checkPriority(subject string, priority int32) error {
+	min, max, err := a.authz.PriorityRange(subject)
+	if err != nil {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	if priority < min || priority > max {
+		return status.Errorf(codes.PermissionDenied, "%s can only use priorities from %d to %d", subject, min, max)
+	}
+	return nil
+}
+
+// checkCredential returns an error if subject can not run jobs as cred
+func (a *API) checkCredential(subject string, cred *jobs.Credential) error {
+	ok, err := a.authz.AllowCredential(subject, cred.UID, cred.GID)
+	if err != nil {
+		return status.Error(codes.PermissionDenied, err.Error())
 	}
 	if !ok {
-		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+		return status.Errorf(codes.PermissionDenied, "%s can not run jobs as uid %d gid %d", subject, cred.UID, cred.GID)
+	}
+	return nil
+}
+
+// Delete removes a job that has ended, or only its output if the request keeps the summary
+func (a *API) Delete(ctx context.Context, req *proto.DeleteRequest) (*proto.DeleteResponse, error) {
+	subject, err := a.authenticate(ctx, authorizer.ActionDelete)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := a.authorizeJob(ctx, subject, authorizer.ActionDelete, req.GetId()); err != nil {
+		return nil, err
 	}
+	err = a.lib.DeleteJob(ctx, req.GetId(), req.GetKeepSummary())
+	if errors.Is(err, ErrJobRunning) {
+		return nil, status.Errorf(codes.FailedPrecondition, "job %d is running, stop it first", req.GetId())
+	}
+	if errors.Is(err, ErrJobQueued) {
+		return nil, status.Errorf(codes.FailedPrecondition, "job %d is queued, stop it first to cancel it", req.GetId())
+	}
+	if errors.Is(err, ErrJobNotFound) {
+		return nil, status.Errorf(codes.NotFound, "job %d not found", req.GetId())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &proto.DeleteResponse{}, nil
+}
 
-	cmd := req.GetCmd()
+// CreateSchedule creates a schedule starting the job of the request on behalf of the caller
+func (a *API) CreateSchedule(ctx context.Context, req *proto.CreateScheduleRequest) (*proto.Schedule, error) {
+	subject, err := a.authenticate(ctx, authorizer.ActionSchedule)
+	if err != nil {
+		return nil, err
+	}
+	if ok, err := a.authz.HasAccess(subject, authorizer.ActionStart); err != nil || !ok {
+		return nil, status.Errorf(codes.PermissionDenied, "%s is not allowed to %s jobs", subject, authorizer.ActionStart)
+	}
+	if len(req.GetJob().GetCmd()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "job must have a command")
+	}
+	spec, opts, err := a.startSpec(subject, req.GetJob())
+	if err != nil {
+		return nil, err
+	}
 
-	job, err := a.lib.StartJob(ctx, cmd, cgroupz.ResourceLimit{
-		CpuWeight: 100,
-		MaxMem:    1e8,
-		MaxIO:     nil,
+	schedule, err := a.lib.CreateSchedule(ctx, Schedule{
+		Owner:       subject,
+		Cron:        req.GetCron(),
+		Timezone:    req.GetTimezone(),
+		Spec:        spec,
+		Options:     opts,
+		Concurrency: concurrencyPolicies[req.GetConcurrency()],
 	})
+	if errors.Is(err, ErrInvalidSchedule) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 	if err != nil {
 		return nil, err
 	}
+	return toProtoSchedule(schedule), nil
+}
 
-	resp := proto.Job{
-		Id:  job.ID,
-		Cmd: cmd,
+// ListSchedules returns the schedules the caller may manage
+func (a *API) ListSchedules(ctx context.Context, req *proto.ListSchedulesRequest) (*proto.ListSchedulesResponse, error) {
+	subject, err := a.authenticate(ctx, authorizer.ActionSchedule)
+	if err != nil {
+		return nil, err
+	}
+	owner := ""
+	if ok, err := a.authz.Authorize(subject, authorizer.ActionSchedule, ""); err != nil || !ok {
+		owner = subject
+	}
+
+	var resp proto.ListSchedulesResponse
+	for _, schedule := range a.lib.ListSchedules(ctx, owner) {
+		resp.Schedules = append(resp.Schedules, toProtoSchedule(schedule))
 	}
 	return &resp, nil
 }
 
-func (a *API) Stop(ctx context.Context, req *proto.StopRequest) (*proto.StopResponse, error) {
-	fmt.Println("Stopping..")
+// DeleteSchedule removes a schedule, the jobs it started are kept
+func (a *API) DeleteSchedule(ctx context.Context, req *proto.DeleteScheduleRequest) (*proto.DeleteScheduleResponse, error) {
+	subject, err := a.authenticate(ctx, authorizer.ActionSchedule)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := a.authorizeSchedule(ctx, subject, req.GetId()); err != nil {
+		return nil, err
+	}
+	err = a.lib.DeleteSchedule(ctx, req.GetId())
+	if errors.Is(err, ErrScheduleNotFound) {
+		return nil, status.Errorf(codes.NotFound, "schedule %d not found", req.GetId())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &proto.DeleteScheduleResponse{}, nil
+}
 
-	userID, err := authn.FromMD(ctx)
+// PauseSchedule pauses a schedule, or resumes it if the request asks to
+func (a *API) PauseSchedule(ctx context.Context, req *proto.PauseScheduleRequest) (*proto.Schedule, error) {
+	subject, err := a.authenticate(ctx, authorizer.ActionSchedule)
 	if err != nil {
-		return nil, status.Error(codes.Unauthenticated, "missing id")
+		return nil, err
+	}
+	if _, err := a.authorizeSchedule(ctx, subject, req.GetId()); err != nil {
+		return nil, err
+	}
+	schedule, err := a.lib.PauseSchedule(ctx, req.GetId(), !req.GetResume())
+	if errors.Is(err, ErrScheduleNotFound) {
+		return nil, status.Errorf(codes.NotFound, "schedule %d not found", req.GetId())
 	}
-	ok, err := a.authz.HasAccess(string(userID), authorizer.ActionStop)
 	if err != nil {
-		return nil, status.Error(codes.Unknown, "")
+		return nil, err
 	}
-	if !ok {
-		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	return toProtoSchedule(schedule), nil
+}
+
+// StartWorkflow starts a graph of jobs on behalf of the caller, who must be allowed to start every job of it
+func (a *API) StartWorkflow(ctx context.Context, req *proto.StartWorkflowRequest) (*proto.Workflow, error) {
+	subject, err := a.authenticate(ctx, authorizer.ActionStart)
+	if err != nil {
+		return nil, err
+	}
+
+	workflow := Workflow{Owner: subject}
+	for _, pnode := range req.GetNodes() {
+		if len(pnode.GetJob().GetCmd()) == 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "node %q must have a command", pnode.GetName())
+		}
+		spec, opts, err := a.startSpec(subject, pnode.GetJob())
+		if err != nil {
+			return nil, err
+		}
+		workflow.Nodes = append(workflow.Nodes, WorkflowNode{
+			Name:      pnode.GetName(),
+			Spec:      spec,
+			Options:   opts,
+			DependsOn: pnode.GetDependsOn(),
+			Condition: nodeConditions[pnode.GetCondition()],
+		})
 	}
 
-	exitCode, jobStatus, err := a.lib.StopJob(ctx, req.GetId())
+	workflow, err = a.lib.StartWorkflow(ctx, workflow)
+	if errors.Is(err, ErrInvalidWorkflow) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 	if err != nil {
 		return nil, err
 	}
-	return &proto.StopResponse{
-		ExitCode: int32(exitCode),
-		Status:   string(jobStatus),
-	}, nil
+	return toProtoWorkflow(workflow), nil
 }
 
-// Stream starts from the beginning of the log
-func (a *API) Stream(req *proto.StreamRequest, server proto.JobService_StreamServer) error {
-	userID, err := authn.FromMD(server.Context())
+func (a *API) GetWorkflow(ctx context.Context, req *proto.GetWorkflowRequest) (*proto.Workflow, error) {
+	subject, err := a.authenticate(ctx, authorizer.ActionGet)
 	if err != nil {
-		return status.Error(codes.Unauthenticated, "missing id")
+		return nil, err
 	}
-	ok, err := a.authz.HasAccess(string(userID), authorizer.ActionStream)
+	workflow, err := a.authorizeWorkflow(ctx, subject, authorizer.ActionGet, req.GetId())
 	if err != nil {
-		return status.Error(codes.Unknown, "")
+		return nil, err
 	}
-	if !ok {
-		return status.Error(codes.Unauthenticated, "unauthenticated")
+	return toProtoWorkflow(workflow), nil
+}
+
+// ListWorkflows returns the workflows the caller may list
+func (a *API) ListWorkflows(ctx context.Context, req *proto.ListWorkflowsRequest) (*proto.ListWorkflowsResponse, error) {
+	subject, err := a.authenticate(ctx, authorizer.ActionList)
+	if err != nil {
+		return nil, err
+	}
+	owner := ""
+	if ok, err := a.authz.Authorize(subject, authorizer.ActionList, ""); err != nil || !ok {
+		owner = subject
+	}
+
+	var resp proto.ListWorkflowsResponse
+	for _, workflow := range a.lib.ListWorkflows(ctx, owner) {
+		resp.Workflows = append(resp.Workflows, toProtoWorkflow(workflow))
+	}
+	return &resp, nil
+}
+
+// StopWorkflow stops the running jobs of a workflow and cancels the jobs that have not started
+func (a *API) StopWorkflow(ctx context.Context, req *proto.StopWorkflowRequest) (*proto.Workflow, error) {
+	subject, err := a.authenticate(ctx, authorizer.ActionStop)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := a.authorizeWorkflow(ctx, subject, authorizer.ActionStop, req.GetId()); err != nil {
+		return nil, err
+	}
+	workflow, err := a.lib.StopWorkflow(ctx, req.GetId(), defaultStopGrace)
+	if errors.Is(err, ErrWorkflowNotFound) {
+		return nil, status.Errorf(codes.NotFound, "workflow %d not found", req.GetId())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toProtoWorkflow(workflow), nil
+}
+
+// Stream starts from the beginning of the log unless an offset or tail option is given.
+func (a *API) Stream(req *proto.StreamRequest, server proto.JobService_StreamServer) error {
+	subject, err := a.authenticate(server.Context(), authorizer.ActionStream)
+	if err != nil {
+		return err
+	}
+
+	if req.GetOffset() < 0 || req.GetStderrOffset() < 0 || req.GetTailBytes() < 0 || req.GetTailLines() < 0 {
+		return status.Error(codes.InvalidArgument, "offset and tail options must not be negative")
+	}
+	opts := jobs.StreamOptions{
+		Source:    jobs.SourceStdout,
+		Offset:    req.GetOffset(),
+		TailBytes: req.GetTailBytes(),
+		TailLines: int(req.GetTailLines()),
+		Follow:    !req.GetNoFollow(),
+	}
+
+	var sources []jobs.StreamOptions
+	switch req.GetSource() {
+	case proto.Source_SOURCE_STDOUT:
+		sources = append(sources, opts)
+	case proto.Source_SOURCE_STDERR:
+		opts.Source = jobs.SourceStderr
+		sources = append(sources, opts)
+	case proto.Source_SOURCE_BOTH:
+		errOpts := opts
+		errOpts.Source = jobs.SourceStderr
+		errOpts.Offset = req.GetStderrOffset()
+		sources = append(sources, opts, errOpts)
+	default:
+		return status.Error(codes.InvalidArgument, "unknown source")
 	}
 
 	fmt.Println("Streaming..")
-	err = a.lib.StreamJob(server.Context(), req.GetId(), &streamWriter{server})
+	record, err := a.authorizeJob(server.Context(), subject, authorizer.ActionStream, req.GetId())
+	if err != nil {
+		return err
+	}
+	if record.Summary {
+		return status.Errorf(codes.FailedPrecondition, "output of job %d has been removed", req.GetId())
+	}
+	if _, err := record.attemptJob(int(req.GetAttempt())); err != nil {
+		return status.Errorf(codes.NotFound, "job %d has no attempt %d", req.GetId(), req.GetAttempt())
+	}
+	if err := a.streamSources(server.Context(), req.GetId(), int(req.GetAttempt()), sources, server); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Attach forwards stdin and terminal resizes sent by the client to the job while streaming the job's stdout and
+// stderr back. The stream ends when the job's output is closed, closing stdin is up to the client.
+func (a *API) Attach(server proto.JobService_AttachServer) error {
+	subject, err := a.authenticate(server.Context(), authorizer.ActionAttach)
+	if err != nil {
+		return err
+	}
+
+	first, err := server.Recv()
+	if err != nil {
+		return err
+	}
+	jobID := first.GetId()
+	record, err := a.authorizeJob(server.Context(), subject, authorizer.ActionAttach, jobID)
+	if err != nil {
+		return err
+	}
+	if !record.Job.HasStdin() {
+		return status.Error(codes.FailedPrecondition, "job was not started with stdin")
+	}
+
+	ctx, cancel := context.WithCancel(server.Context())
+	defer cancel()
+
+	// the goroutine is not waited on, its Recv call returns once this handler returns
+	stdinErr := make(chan error, 1)
+	go func() {
+		if err := a.forwardStdin(ctx, jobID, first, server); err != nil {
+			stdinErr <- err
+			cancel()
+		}
+	}()
+
+	sources := []jobs.StreamOptions{
+		{Source: jobs.SourceStdout, Follow: true},
+		{Source: jobs.SourceStderr, Follow: true},
+	}
+	err = a.streamSources(ctx, jobID, 0, sources, server)
+	select {
+	case err := <-stdinErr:
+		return err
+	default:
+	}
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
+// Watch streams the lifecycle events of the requested job, or of every job when no id is given, until the client
+// cancels. Events that happened before the call are not sent.
+func (a *API) Watch(req *proto.WatchRequest, server proto.JobService_WatchServer) error {
+	subject, err := a.authenticate(server.Context(), authorizer.ActionWatch)
+	if err != nil {
+		return err
+	}
+
+	// the subject only sees the events of jobs they may watch
+	owner := ""
+	if ok, err := a.authz.Authorize(subject, authorizer.ActionWatch, ""); err != nil || !ok {
+		owner = subject
+	}
+	if req.GetId() != 0 {
+		if _, err := a.authorizeJob(server.Context(), subject, authorizer.ActionWatch, req.GetId()); err != nil {
+			return err
+		}
+	}
+	watcher, err := a.lib.Watch(server.Context(), req.GetId(), owner)
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	for {
+		select {
+		case <-server.Context().Done():
+			return server.Context().Err()
+		case event, ok := <-watcher.C:
+			if !ok {
+				return status.Error(codes.ResourceExhausted, watcher.Err().Error())
+			}
+			if err := server.Send(toProtoEvent(event)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// authenticate returns the subject of the caller, who must be allowed to perform action on at least their own jobs
+func (a *API) authenticate(ctx context.Context, action string) (string, error) {
+	userID, err := authn.FromMD(ctx)
+	if err != nil {
+		return "", status.Error(codes.Unauthenticated, "missing id")
+	}
+	ok, err := a.authz.HasAccess(string(userID), action)
+	if err != nil || !ok {
+		return "", status.Errorf(codes.PermissionDenied, "%s is not allowed to %s jobs", userID, action)
+	}
+	return string(userID), nil
+}
+
+// authorizeJob returns the job with jobID if subject may perform action on it. A job the subject may not even get
+// is reported as not found, so its existence is not leaked.
+func (a *API) authorizeJob(ctx context.Context, subject string, action string, jobID int32) (JobRecord, error) {
+	record, err := a.lib.GetJob(ctx, jobID)
+	if errors.Is(err, ErrJobNotFound) {
+		return JobRecord{}, status.Errorf(codes.NotFound, "job %d not found", jobID)
+	}
+	if err != nil {
+		return JobRecord{}, err
+	}
+	ok, err := a.authz.Authorize(subject, action, record.Owner)
+	if err != nil {
+		return JobRecord{}, status.Error(codes.PermissionDenied, err.Error())
+	}
+	if !ok {
+		if visible, _ := a.authz.Authorize(subject, authorizer.ActionGet, record.Owner); visible {
+			return JobRecord{}, status.Errorf(codes.PermissionDenied, "%s is not allowed to %s job %d", subject, action, jobID)
+		}
+		return JobRecord{}, status.Errorf(codes.NotFound, "job %d not found", jobID)
+	}
+	return record, nil
+}
+
+// authorizeSchedule returns the schedule with id if subject may manage it, schedules of others are reported as not found
+func (a *API) authorizeSchedule(ctx context.Context, subject string, id int32) (Schedule, error) {
+	schedule, err := a.lib.GetSchedule(ctx, id)
+	if errors.Is(err, ErrScheduleNotFound) {
+		return Schedule{}, status.Errorf(codes.NotFound, "schedule %d not found", id)
+	}
+	if err != nil {
+		return Schedule{}, err
+	}
+	ok, err := a.authz.Authorize(subject, authorizer.ActionSchedule, schedule.Owner)
+	if err != nil {
+		return Schedule{}, status.Error(codes.PermissionDenied, err.Error())
+	}
+	if !ok {
+		return Schedule{}, status.Errorf(codes.NotFound, "schedule %d not found", id)
+	}
+	return schedule, nil
+}
+
+// authorizeWorkflow returns the workflow with id if subject may perform action on it, as on the jobs of its owner.
+// A workflow the subject may not even get is reported as not found.
+func (a *API) authorizeWorkflow(ctx context.Context, subject string, action string, id int32) (Workflow, error) {
+	workflow, err := a.lib.GetWorkflow(ctx, id)
+	if errors.Is(err, ErrWorkflowNotFound) {
+		return Workflow{}, status.Errorf(codes.NotFound, "workflow %d not found", id)
+	}
+	if err != nil {
+		return Workflow{}, err
+	}
+	ok, err := a.authz.Authorize(subject, action, workflow.Owner)
+	if err != nil {
+		return Workflow{}, status.Error(codes.PermissionDenied, err.Error())
+	}
+	if !ok {
+		if visible, _ := a.authz.Authorize(subject, authorizer.ActionGet, workflow.Owner); visible {
+			return Workflow{}, status.Errorf(codes.PermissionDenied, "%s is not allowed to %s workflow %d", subject, action, id)
+		}
+		return Workflow{}, status.Errorf(codes.NotFound, "workflow %d not found", id)
+	}
+	return workflow, nil
+}
+
+// forwardStdin writes the stdin of req and every following request received on server to the job,
+// until the client stops sending or ctx is done.
+func (a *API) forwardStdin(ctx context.Context, jobID int32, req *proto.AttachRequest, server proto.JobService_AttachServer) error {
+	for {
+		if len(req.GetStdin()) > 0 {
+			if err := a.lib.WriteStdin(ctx, jobID, req.GetStdin()); err != nil {
+				return attachError(jobID, err)
+			}
+		}
+		if req.GetCloseStdin() {
+			if err := a.lib.CloseStdin(ctx, jobID); err != nil {
+				return attachError(jobID, err)
+			}
+		}
+		if size := req.GetResize(); size != nil {
+			if err := a.lib.ResizeTerminal(ctx, jobID, uint16(size.GetRows()), uint16(size.GetCols())); err != nil {
+				return attachError(jobID, err)
+			}
+		}
+
+		var err error
+		req, err = server.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// attachError converts an error writing to the stdin or terminal of a job to a status. The stdin of a job is only
+// open while its command runs, so most errors mean it has not started yet or has exited.
+func attachError(jobID int32, err error) error {
+	switch {
+	case errors.Is(err, ErrJobNotFound):
+		return status.Errorf(codes.NotFound, "job %d not found", jobID)
+	case errors.Is(err, jobs.ErrNoStdin), errors.Is(err, jobs.ErrNotRunning):
+		return status.Errorf(codes.FailedPrecondition, "job %d is not running", jobID)
+	case errors.Is(err, jobs.ErrNoTTY):
+		return status.Errorf(codes.FailedPrecondition, "job %d has no terminal", jobID)
+	case errors.Is(err, syscall.EPIPE), errors.Is(err, os.ErrClosed):
+		return status.Errorf(codes.FailedPrecondition, "job %d has exited", jobID)
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+// streamSources streams each of the sources of an attempt of the job concurrently to sender.
+func (a *API) streamSources(ctx context.Context, jobID int32, attempt int, sources []jobs.StreamOptions, sender responseSender) error {
+	var mu sync.Mutex
+	errch := make(chan error, len(sources))
+	for _, opts := range sources {
+		writer := &streamWriter{sender: sender, source: toProtoSource(opts.Source), mu: &mu}
+		go func(opts jobs.StreamOptions) {
+			errch <- a.lib.StreamJob(ctx, jobID, attempt, opts, writer)
+		}(opts)
+	}
+	var errs error
+	for range sources {
+		errs = multierr.Append(errs, <-errch)
+	}
+	return errs
+}
+
+func toProtoSource(source jobs.Source) proto.Source {
+	if source == jobs.SourceStderr {
+		return proto.Source_SOURCE_STDERR
+	}
+	return proto.Source_SOURCE_STDOUT
+}
+
+var protoEventTypes = map[EventType]proto.EventType{
+	EventCreated:   proto.EventType_EVENT_CREATED,
+	EventStarted:   proto.EventType_EVENT_STARTED,
+	EventFailed:    proto.EventType_EVENT_FAILED,
+	EventExited:    proto.EventType_EVENT_EXITED,
+	EventStopped:   proto.EventType_EVENT_STOPPED,
+	EventOOMKilled: proto.EventType_EVENT_OOM_KILLED,
+	EventRemoved:   proto.EventType_EVENT_REMOVED,
+	EventQueued:    proto.EventType_EVENT_QUEUED,
+	EventCancelled: proto.EventType_EVENT_CANCELLED,
+	EventPreempted: proto.EventType_EVENT_PREEMPTED,
+	EventRetrying:  proto.EventType_EVENT_RETRYING,
+	EventTimedOut:  proto.EventType_EVENT_TIMED_OUT,
+}
+
+func toProtoEvent(event Event) *proto.Event {
+	pevent := proto.Event{
+		Type:     protoEventTypes[event.Type],
+		Id:       event.JobID,
+		Owner:    event.Owner,
+		Time:     timestamppb.New(event.Time),
+		ExitCode: int32(event.ExitCode),
+		Attempt:  int32(event.Attempt),
+	}
+	if event.Signal != 0 {
+		pevent.Signal = jobs.SignalName(event.Signal)
+	}
+	if event.Err != nil {
+		pevent.Error = event.Err.Error()
+	}
+	return &pevent
+}
+
+// outputPolicies maps the policies of requests to jobs, the default maps to an empty policy
+var outputPolicies = map[proto.OutputPolicy]jobs.OutputPolicy{
+	proto.OutputPolicy_OUTPUT_POLICY_RING: jobs.OutputRing,
+	proto.OutputPolicy_OUTPUT_POLICY_STOP: jobs.OutputStop,
+	proto.OutputPolicy_OUTPUT_POLICY_KILL: jobs.OutputKill,
+}
+
+func toProtoOutputPolicy(policy jobs.OutputPolicy) proto.OutputPolicy {
+	for protoPolicy, p := range outputPolicies {
+		if p == policy {
+			return protoPolicy
+		}
+	}
+	return proto.OutputPolicy_OUTPUT_POLICY_DEFAULT
+}
+
+func toProtoJob(record JobRecord) *proto.Job {
+	state := record.Job.State()
+	spec := record.Job.Spec()
+	job := proto.Job{
+		Id:       record.ID,
+		Cmd:      record.Command,
+		Status:   string(state.Status),
+		Owner:    record.Owner,
+		Tty:      record.Job.HasTTY(),
+		ExitCode: int32(state.ExitCode),
+
+		OutputLimit:     spec.OutputLimit,
+		OutputPolicy:    toProtoOutputPolicy(spec.OutputPolicy),
+		OutputTruncated: state.OutputTruncated,
+		Summary:         record.Summary,
+		QueuePosition:   int32(record.QueuePosition),
+		Priority:        record.Priority,
+		Attempt:         int32(record.Attempt),
+		MaxAttempts:     int32(record.Retry.MaxAttempts),
+	}
+	if job.MaxAttempts < 1 {
+		job.MaxAttempts = 1
+	}
+	if !record.RetryAt.IsZero() {
+		job.RetryAt = timestamppb.New(record.RetryAt)
+	}
+	if record.Timeout > 0 {
+		job.Timeout = durationpb.New(record.Timeout)
+	}
+	if events := state.MemoryEvents; events != (cgroupz.MemoryEvents{}) {
+		job.MemoryEvents = &proto.MemoryEvents{Max: events.Max, Oom: events.OOM, OomKill: events.OOMKill}
+	}
+	for _, attempt := range record.Attempts {
+		pattempt := proto.Attempt{
+			Number:          int32(attempt.Number),
+			Status:          string(attempt.Status),
+			ExitCode:        int32(attempt.ExitCode),
+			StartedAt:       timestamppb.New(attempt.StartedAt),
+			EndedAt:         timestamppb.New(attempt.EndedAt),
+			OutputTruncated: attempt.OutputTruncated,
+		}
+		if attempt.Signal != 0 {
+			pattempt.Signal = jobs.SignalName(attempt.Signal)
+		}
+		job.Attempts = append(job.Attempts, &pattempt)
+	}
+	if state.Signal != 0 {
+		job.Signal = jobs.SignalName(state.Signal)
+	}
+	if !state.StartedAt.IsZero() {
+		job.StartedAt = timestamppb.New(state.StartedAt)
+	}
+	if !state.EndedAt.IsZero() {
+		job.EndedAt = timestamppb.New(state.EndedAt)
+	}
+	return &job
+}
+
+func toProtoStats(id int32, stats cgroupz.Stats) *proto.JobStats {
+	pstats := proto.JobStats{
+		Id:                  id,
+		CpuUsage:            durationpb.New(time.Duration(stats.CPUUsageUsec) * time.Microsecond),
+		CpuUser:             durationpb.New(time.Duration(stats.CPUUserUsec) * time.Microsecond),
+		CpuSystem:           durationpb.New(time.Duration(stats.CPUSystemUsec) * time.Microsecond),
+		CpuThrottledPeriods: stats.CPUThrottledPeriods,
+		CpuThrottled:        durationpb.New(time.Duration(stats.CPUThrottledUsec) * time.Microsecond),
+		MemoryCurrent:       stats.MemoryCurrent,
+		MemoryPeak:          stats.MemoryPeak,
+		MemoryStat:          stats.MemoryStat,
+		ReadBytes:           stats.ReadBytes(),
+		WrittenBytes:        stats.WrittenBytes(),
+		PidsCurrent:         stats.PidsCurrent,
+	}
+	for _, io := range stats.IO {
+		pstats.Io = append(pstats.Io, &proto.IOStat{
+			Device:       fmt.Sprintf("%d:%d", io.Maj, io.Min),
+			ReadBytes:    io.RBytes,
+			WrittenBytes: io.WBytes,
+			ReadIos:      io.RIOs,
+			WriteIos:     io.WIOs,
+		})
+	}
+	return &pstats
+}
+
+func toProtoStatsSample(now time.Time, stats cgroupz.Stats, rates cgroupz.Rates) *proto.StatsSample {
+	return &proto.StatsSample{
+		Time:                  timestamppb.New(now),
+		CpuPercent:            rates.CPUPercent,
+		MemoryCurrent:         stats.MemoryCurrent,
+		MemoryPeak:            stats.MemoryPeak,
+		ReadBytesPerSecond:    rates.ReadBytesPerSec,
+		WrittenBytesPerSecond: rates.WrittenBytesPerSec,
+		PidsCurrent:           stats.PidsCurrent,
+	}
+}
+
+// toProtoUsage returns the stats of a job that has ended, of which only the summary of its usage is kept
+func toProtoUsage(id int32, usage jobs.Usage) *proto.JobStats {
+	return &proto.JobStats{
+		Id:           id,
+		Final:        true,
+		CpuUsage:     durationpb.New(usage.CPU),
+		MemoryPeak:   usage.PeakMemory,
+		ReadBytes:    usage.ReadBytes,
+		WrittenBytes: usage.WrittenBytes,
+	}
+}
+
+// concurrencyPolicies maps the concurrency policies of requests to schedules
+var concurrencyPolicies = map[proto.ConcurrencyPolicy]ConcurrencyPolicy{
+	proto.ConcurrencyPolicy_CONCURRENCY_POLICY_ALLOW:   ConcurrencyAllow,
+	proto.ConcurrencyPolicy_CONCURRENCY_POLICY_FORBID:  ConcurrencyForbid,
+	proto.ConcurrencyPolicy_CONCURRENCY_POLICY_REPLACE: ConcurrencyReplace,
+}
+
+func toProtoSchedule(schedule Schedule) *proto.Schedule {
+	pschedule := proto.Schedule{
+		Id:        schedule.ID,
+		Owner:     schedule.Owner,
+		Cron:      schedule.Cron,
+		Timezone:  schedule.Timezone,
+		Cmd:       schedule.Spec.Command,
+		Paused:    schedule.Paused,
+		History:   schedule.History,
+		CreatedAt: timestamppb.New(schedule.CreatedAt),
+	}
+	for protoPolicy, policy := range concurrencyPolicies {
+		if policy == schedule.Concurrency {
+			pschedule.Concurrency = protoPolicy
+		}
+	}
+	if !schedule.NextRun.IsZero() {
+		pschedule.NextRun = timestamppb.New(schedule.NextRun)
+	}
+	if !schedule.LastRun.IsZero() {
+		pschedule.LastRun = timestamppb.New(schedule.LastRun)
+	}
+	return &pschedule
+}
+
+// nodeConditions maps the conditions of requests to workflow nodes
+var nodeConditions = map[proto.Condition]NodeCondition{
+	proto.Condition_CONDITION_ON_SUCCESS: ConditionOnSuccess,
+	proto.Condition_CONDITION_ON_FAILURE: ConditionOnFailure,
+	proto.Condition_CONDITION_ALWAYS:     ConditionAlways,
+}
+
+func toProtoWorkflow(workflow Workflow) *proto.Workflow {
+	pworkflow := proto.Workflow{
+		Id:        workflow.ID,
+		Owner:     workflow.Owner,
+		Status:    string(workflow.Status),
+		CreatedAt: timestamppb.New(workflow.CreatedAt),
+	}
+	if !workflow.EndedAt.IsZero() {
+		pworkflow.EndedAt = timestamppb.New(workflow.EndedAt)
+	}
+	for _, node := range workflow.Nodes {
+		pnode := proto.WorkflowNodeStatus{
+			Name:      node.Name,
+			Cmd:       node.Spec.Command,
+			DependsOn: node.DependsOn,
+			Status:    string(node.Status),
+			JobId:     node.JobID,
+		}
+		for protoCondition, condition := range nodeConditions {
+			if condition == node.Condition {
+				pnode.Condition = protoCondition
+			}
+		}
+		pworkflow.Nodes = append(pworkflow.Nodes, &pnode)
+	}
+	return &pworkflow
+}
+
+// responseSender is implemented by the server side of every rpc streaming StreamResponses
+type responseSender interface {
+	Send(*proto.StreamResponse) error
+	Context() context.Context
+}
+
+// streamWriter sends each chunk of output tagged with its source. grpc streams do not support concurrent
+// sends, so writers streaming different sources to the same server stream share mu.
 type streamWriter struct {
-	proto.JobService_StreamServer
+	sender responseSender
+	source proto.Source
+	mu     *sync.Mutex
+	// bytes skipped before the next chunk
+	truncated int64
+}
+
+// Truncated is sent along with the next chunk
+func (s *streamWriter) Truncated(n int64) error {
+	s.truncated += n
+	return nil
 }
 
-func (s *streamWriter) Write(p []byte) (int, error) {
+func (s *streamWriter) WriteAt(p []byte, off int64) (int, error) {
 	select {
-	case <-s.Context().Done():
-		return 0, s.Context().Err()
+	case <-s.sender.Context().Done():
+		return 0, s.sender.Context().Err()
 	default:
-		if err := s.Send(&proto.StreamResponse{Stream: p}); err != nil {
+		s.mu.Lock()
+		err := s.sender.Send(&proto.StreamResponse{Stream: p, Offset: off, Source: s.source, Truncated: s.truncated})
+		s.truncated = 0
+		s.mu.Unlock()
+		if err != nil {
 			return 0, err
 		}
 	}
@@ -0,0 +1,73 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"job_runner/pkg/authorizer"
+	"job_runner/pkg/jobs"
+	"job_runner/proto"
+)
+
+func Test_API_StartSpecUser(t *testing.T) {
+	nobody := &jobs.Credential{UID: 65534, GID: 65534}
+	a := &API{authz: authorizer.NewAuthorizer(), limits: DefaultLimitConfig(), defaultUser: nobody}
+
+	tests := []struct {
+		name    string
+		subject string
+		user    string
+		cred    *jobs.Credential
+		code    codes.Code
+	}{
+		{name: "default user", subject: "bob", cred: nobody},
+		{name: "allowed user", subject: "bob", user: "65534:65534", cred: nobody},
+		{name: "root by id", subject: "bob", user: "0:0", code: codes.PermissionDenied},
+		{name: "root by name", subject: "bob", user: "root", code: codes.PermissionDenied},
+		{name: "root group", subject: "bob", user: "65534:0", code: codes.PermissionDenied},
+		{name: "admin runs as root", subject: "alice", user: "0:0", cred: &jobs.Credential{}},
+		{name: "invalid user", subject: "alice", user: ":0", code: codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, _, err := a.startSpec(tt.subject, &proto.StartRequest{Cmd: []string{"true"}, User: tt.user})
+			if tt.code != codes.OK {
+				require.Equal(t, tt.code, status.Code(err), err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.cred, spec.Credential)
+		})
+	}
+}
+
+func Test_API_AttachError(t *testing.T) {
+	s := newTestService(t, ServiceConfig{MaxRunning: 1})
+	startTestJob(t, s, StartOptions{}, "sleep", "5")
+	queued, err := s.StartJob(context.Background(), "alice", jobs.Spec{Command: []string{"cat"}, Limits: testLimits, Stdin: true}, StartOptions{})
+	require.NoError(t, err)
+	exited, err := s.StartJob(context.Background(), "alice", jobs.Spec{Command: []string{"true"}, Limits: testLimits, Stdin: true}, StartOptions{Priority: 1})
+	require.NoError(t, err)
+
+	// the stdin of a queued job is not open yet
+	err = s.WriteStdin(context.Background(), queued.ID, []byte("in"))
+	require.Equal(t, codes.FailedPrecondition, status.Code(attachError(queued.ID, err)), err)
+	err = s.ResizeTerminal(context.Background(), queued.ID, 24, 80)
+	require.Equal(t, codes.FailedPrecondition, status.Code(attachError(queued.ID, err)), err)
+
+	// and it is closed once the job has exited
+	stopTestJob(t, s, 1)
+	_, err = s.WaitJob(context.Background(), exited.ID)
+	require.NoError(t, err)
+	err = s.WriteStdin(context.Background(), exited.ID, []byte("in"))
+	require.Equal(t, codes.FailedPrecondition, status.Code(attachError(exited.ID, err)), err)
+	err = s.CloseStdin(context.Background(), exited.ID)
+	require.Equal(t, codes.FailedPrecondition, status.Code(attachError(exited.ID, err)), err)
+
+	err = s.WriteStdin(context.Background(), 42, []byte("in"))
+	require.Equal(t, codes.NotFound, status.Code(attachError(42, err)), err)
+}
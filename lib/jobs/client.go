@@ -5,21 +5,33 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"job_runner/lib/utils"
+	"job_runner/pkg/jobs"
 	"job_runner/proto"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 type Client struct {
-	conn proto.JobServiceClient
-	out  io.Writer
+	conn   proto.JobServiceClient
+	out    io.Writer
+	errOut io.Writer
 }
 
-func NewClient(conn grpc.ClientConnInterface, out io.Writer) *Client {
+// NewClient returns a Client that prints the stdout of streamed jobs to out and their stderr to errOut.
+func NewClient(conn grpc.ClientConnInterface, out io.Writer, errOut io.Writer) *Client {
 	client := proto.NewJobServiceClient(conn)
 	return &Client{
-		conn: client,
-		out:  out,
+		conn:   client,
+		out:    out,
+		errOut: errOut,
 	}
 }
 
@@ -27,40 +39,325 @@ func (c *Client) Get(ctx context.Context, jobID int32) (*proto.Job, error) {
 	return c.conn.Get(ctx, &proto.GetRequest{Id: jobID})
 }
 
-func (c *Client) Start(ctx context.Context, cmd []string) (*proto.Job, error) {
-	return c.conn.Start(ctx, &proto.StartRequest{Cmd: cmd})
+func (c *Client) Stats(ctx context.Context, jobID int32) (*proto.JobStats, error) {
+	return c.conn.Stats(ctx, &proto.StatsRequest{Id: jobID})
 }
 
-func (c *Client) Stop(ctx context.Context, id int32) (*proto.StopResponse, error) {
-	return c.conn.Stop(ctx, &proto.StopRequest{Id: id})
+func (c *Client) Start(ctx context.Context, req *proto.StartRequest) (*proto.Job, error) {
+	return c.conn.Start(ctx, req)
 }
 
-func (c *Client) Stream(ctx context.Context, id int32) error {
-	stream, err := c.conn.Stream(ctx, &proto.StreamRequest{Id: id})
+func (c *Client) Stop(ctx context.Context, req *proto.StopRequest) (*proto.StopResponse, error) {
+	return c.conn.Stop(ctx, req)
+}
+
+func (c *Client) Signal(ctx context.Context, req *proto.SignalRequest) error {
+	_, err := c.conn.Signal(ctx, req)
+	return err
+}
+
+// Delete removes a job that has ended, with keepSummary only its output is removed
+func (c *Client) Delete(ctx context.Context, jobID int32, keepSummary bool) error {
+	_, err := c.conn.Delete(ctx, &proto.DeleteRequest{Id: jobID, KeepSummary: keepSummary})
+	return err
+}
+
+// UpdatePriority changes the priority of a job that has not ended
+func (c *Client) UpdatePriority(ctx context.Context, jobID int32, priority int32) (*proto.Job, error) {
+	return c.conn.UpdatePriority(ctx, &proto.UpdatePriorityRequest{Id: jobID, Priority: priority})
+}
+
+// CreateSchedule creates a schedule starting the job of req.Job every time its cron expression matches
+func (c *Client) CreateSchedule(ctx context.Context, req *proto.CreateScheduleRequest) (*proto.Schedule, error) {
+	return c.conn.CreateSchedule(ctx, req)
+}
+
+func (c *Client) ListSchedules(ctx context.Context) ([]*proto.Schedule, error) {
+	resp, err := c.conn.ListSchedules(ctx, &proto.ListSchedulesRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetSchedules(), nil
+}
+
+func (c *Client) DeleteSchedule(ctx context.Context, id int32) error {
+	_, err := c.conn.DeleteSchedule(ctx, &proto.DeleteScheduleRequest{Id: id})
+	return err
+}
+
+// PauseSchedule pauses the schedule, or resumes it if resume is set
+func (c *Client) PauseSchedule(ctx context.Context, id int32, resume bool) (*proto.Schedule, error) {
+	return c.conn.PauseSchedule(ctx, &proto.PauseScheduleRequest{Id: id, Resume: resume})
+}
+
+// StartWorkflow starts the graph of jobs of req
+func (c *Client) StartWorkflow(ctx context.Context, req *proto.StartWorkflowRequest) (*proto.Workflow, error) {
+	return c.conn.StartWorkflow(ctx, req)
+}
+
+func (c *Client) GetWorkflow(ctx context.Context, id int32) (*proto.Workflow, error) {
+	return c.conn.GetWorkflow(ctx, &proto.GetWorkflowRequest{Id: id})
+}
+
+func (c *Client) ListWorkflows(ctx context.Context) ([]*proto.Workflow, error) {
+	resp, err := c.conn.ListWorkflows(ctx, &proto.ListWorkflowsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetWorkflows(), nil
+}
+
+// StopWorkflow stops the running jobs of the workflow and cancels those that have not started
+func (c *Client) StopWorkflow(ctx context.Context, id int32) (*proto.Workflow, error) {
+	return c.conn.StopWorkflow(ctx, &proto.StopWorkflowRequest{Id: id})
+}
+
+func (c *Client) List(ctx context.Context, req *proto.ListRequest) (*proto.ListResponse, error) {
+	return c.conn.List(ctx, req)
+}
+
+// Wait blocks until the job has ended or timeout passes, a zero timeout waits until the job ends
+func (c *Client) Wait(ctx context.Context, jobID int32, timeout time.Duration) (*proto.WaitResponse, error) {
+	req := proto.WaitRequest{Id: jobID}
+	if timeout > 0 {
+		req.Timeout = durationpb.New(timeout)
+	}
+	return c.conn.Wait(ctx, &req)
+}
+
+// ExitCode returns the exit code a shell would report for the job described by resp,
+// which is 128 plus the signal number for jobs stopped by a signal
+func ExitCode(resp *proto.WaitResponse) int {
+	if resp.GetSignal() != "" {
+		if sig, err := jobs.ParseSignal(resp.GetSignal()); err == nil {
+			return 128 + int(sig)
+		}
+	}
+	return int(resp.GetExitCode())
+}
+
+// Watch prints the lifecycle events of the job with jobID, or of every job when jobID is 0, one per line
+// until ctx is done.
+func (c *Client) Watch(ctx context.Context, jobID int32) error {
+	stream, err := c.conn.Watch(ctx, &proto.WatchRequest{Id: jobID})
 	if err != nil {
 		return err
 	}
+	for {
+		event, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("recv: %w", err)
+		}
+		fmt.Fprintln(c.out, formatEvent(event))
+	}
+}
+
+// Top prints the resource usage of the running job with jobID as it is sampled every interval, until the job ends.
+// A zero interval uses the server default.
+func (c *Client) Top(ctx context.Context, jobID int32, interval time.Duration) error {
+	req := &proto.StreamStatsRequest{Id: jobID}
+	if interval != 0 {
+		req.Interval = durationpb.New(interval)
+	}
+	stream, err := c.conn.StreamStats(ctx, req)
+	if err != nil {
+		return err
+	}
+	for i := 0; ; i++ {
+		sample, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			fmt.Fprintf(c.out, "job %d has ended\n", jobID)
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("recv: %w", err)
+		}
+		// errors such as the job not running arrive with the first sample, the header is printed once it has
+		if i == 0 {
+			fmt.Fprintf(c.out, topFormat, "TIME", "CPU%", "MEM", "PEAK", "READ/S", "WRITE/S", "PIDS")
+		}
+		fmt.Fprintf(c.out, topFormat,
+			sample.GetTime().AsTime().Local().Format("15:04:05"),
+			strconv.FormatFloat(sample.GetCpuPercent(), 'f', 1, 64),
+			utils.FormatBytes(sample.GetMemoryCurrent()),
+			utils.FormatBytes(sample.GetMemoryPeak()),
+			utils.FormatBytes(uint64(sample.GetReadBytesPerSecond())),
+			utils.FormatBytes(uint64(sample.GetWrittenBytesPerSecond())),
+			strconv.FormatUint(sample.GetPidsCurrent(), 10),
+		)
+	}
+}
+
+// topFormat lays out a row of Top
+const topFormat = "%-8s %7s %8s %8s %8s %8s %5s\n"
+
+func formatEvent(event *proto.Event) string {
+	line := fmt.Sprintf("%s job %d %s",
+		event.GetTime().AsTime().Local().Format(time.RFC3339),
+		event.GetId(),
+		strings.ToLower(strings.TrimPrefix(event.GetType().String(), "EVENT_")),
+	)
+	switch event.GetType() {
+	case proto.EventType_EVENT_EXITED:
+		line += fmt.Sprintf(" code=%d", event.GetExitCode())
+	case proto.EventType_EVENT_STOPPED, proto.EventType_EVENT_OOM_KILLED:
+		line += " signal=" + event.GetSignal()
+	case proto.EventType_EVENT_FAILED:
+		line += fmt.Sprintf(" error=%q", event.GetError())
+	case proto.EventType_EVENT_RETRYING:
+		line += fmt.Sprintf(" attempt=%d", event.GetAttempt())
+	}
+	return line
+}
+
+// maxStreamRetries is the number of times Stream reconnects after losing the connection without
+// receiving any new output in between.
+const maxStreamRetries = 5
+
+// Stream prints the output of a job. If the connection to the server drops, Stream reconnects and resumes
+// from the offset following the last chunk it received instead of replaying the whole output.
+func (c *Client) Stream(ctx context.Context, req *proto.StreamRequest) error {
+	retries := 0
+	backoff := 100 * time.Millisecond
+	for {
+		resumed, err := c.stream(ctx, req)
+		if err == nil {
+			return nil
+		}
+		if status.Code(err) != codes.Unavailable {
+			return err
+		}
+		if resumed {
+			retries = 0
+			backoff = 100 * time.Millisecond
+		}
+		retries++
+		if retries > maxStreamRetries {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// stream receives a single server stream. req is updated to resume after the last chunk received,
+// resumed reports whether any chunk was received.
+func (c *Client) stream(ctx context.Context, req *proto.StreamRequest) (resumed bool, err error) {
+	stream, err := c.conn.Stream(ctx, req, grpc.WaitForReady(true))
+	if err != nil {
+		return false, err
+	}
 	defer func() {
-		err = stream.CloseSend()
+		_ = stream.CloseSend()
 	}()
 
-loop:
 	for {
 		select {
 		case <-ctx.Done():
-			return ctx.Err()
+			return resumed, ctx.Err()
 		default:
 			resp, err := stream.Recv()
+			if resp.GetTruncated() > 0 {
+				fmt.Fprintf(c.errOut, "[%d bytes of output truncated]\n", resp.GetTruncated())
+			}
 			if len(resp.GetStream()) > 0 {
-				fmt.Fprint(c.out, string(resp.GetStream()))
+				next := resp.GetOffset() + int64(len(resp.GetStream()))
+				if resp.GetSource() == proto.Source_SOURCE_STDERR {
+					fmt.Fprint(c.errOut, string(resp.GetStream()))
+				} else {
+					fmt.Fprint(c.out, string(resp.GetStream()))
+				}
+				// the tail options only apply to the first request, from now on we resume by offset
+				if req.GetSource() == proto.Source_SOURCE_BOTH && resp.GetSource() == proto.Source_SOURCE_STDERR {
+					req.StderrOffset = next
+				} else {
+					req.Offset = next
+				}
+				req.TailBytes = 0
+				req.TailLines = 0
+				resumed = true
 			}
 			if errors.Is(err, io.EOF) {
-				break loop
+				return resumed, nil
 			}
 			if err != nil {
-				return fmt.Errorf("recv: %w", err)
+				return resumed, fmt.Errorf("recv: %w", err)
 			}
 		}
 	}
-	return nil
+}
+
+// Attach sends everything read from in to the stdin of the job and prints the job's output until the job exits.
+// When in reaches EOF, the job's stdin is closed. Every size received on sizes resizes the job's terminal,
+// sizes may be nil for jobs without a tty.
+func (c *Client) Attach(ctx context.Context, id int32, in io.Reader, sizes <-chan *proto.TerminalSize) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := c.conn.Attach(ctx)
+	if err != nil {
+		return err
+	}
+	// grpc streams do not support concurrent sends
+	var mu sync.Mutex
+	send := func(req *proto.AttachRequest) error {
+		mu.Lock()
+		defer mu.Unlock()
+		return stream.Send(req)
+	}
+	if err := send(&proto.AttachRequest{Id: id}); err != nil {
+		return fmt.Errorf("send: %w", err)
+	}
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case size := <-sizes:
+				if err := send(&proto.AttachRequest{Resize: size}); err != nil {
+					return
+				}
+			}
+		}
+	}()
+
+	go func() {
+		buf := make([]byte, 32*1024)
+		for {
+			n, err := in.Read(buf)
+			if n > 0 {
+				if sendErr := send(&proto.AttachRequest{Stdin: buf[:n]}); sendErr != nil {
+					return
+				}
+			}
+			if err != nil {
+				// on EOF as well as on any other read error, the job will not receive more input
+				_ = send(&proto.AttachRequest{CloseStdin: true})
+				return
+			}
+		}
+	}()
+
+	for {
+		resp, err := stream.Recv()
+		if resp.GetSource() == proto.Source_SOURCE_STDERR {
+			fmt.Fprint(c.errOut, string(resp.GetStream()))
+		} else {
+			fmt.Fprint(c.out, string(resp.GetStream()))
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("recv: %w", err)
+		}
+	}
 }
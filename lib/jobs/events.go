@@ -0,0 +1,133 @@
+package jobs
+
+import (
+	"errors"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// ErrWatcherBehind is returned to a watcher whose events were dropped because it did not keep up
+var ErrWatcherBehind = errors.New("watcher fell behind, events were dropped")
+
+// size of the buffer of each watcher, a watcher that falls further behind is closed
+const watchBuffer = 256
+
+type EventType string
+
+const (
+	// EventCreated is published when a job is added to the service
+	EventCreated EventType = "created"
+	// EventQueued is published when a job waits for a running job to end before it starts
+	EventQueued EventType = "queued"
+	// EventCancelled is published when a queued job is cancelled before it started
+	EventCancelled EventType = "cancelled"
+	// EventPreempted is published when a running job is stopped to make room for a job with a higher priority
+	EventPreempted EventType = "preempted"
+	// EventTimedOut is published when a running job is stopped for running longer than its timeout
+	EventTimedOut EventType = "timed_out"
+	// EventStarted is published when the command of a job is running
+	EventStarted EventType = "started"
+	// EventFailed is published when the command of a job could not be run or waited on
+	EventFailed EventType = "failed"
+	// EventExited is published when the command of a job exits, ExitCode is set
+	EventExited EventType = "exited"
+	// EventStopped is published when the command of a job is stopped by a signal, Signal is set
+	EventStopped EventType = "stopped"
+	// EventOOMKilled is published when the command of a job is killed by the OOM killer for exceeding its
+	// memory limit, Signal is set
+	EventOOMKilled EventType = "oom_killed"
+	// EventRemoved is published when a job is removed from the service
+	EventRemoved EventType = "removed"
+	// EventRetrying is published when a job that failed is run again once its backoff passes at Time, Attempt is set
+	EventRetrying EventType = "retrying"
+)
+
+// Event is a change in the lifecycle of a job
+type Event struct {
+	Type     EventType
+	JobID    int32
+	Owner    string
+	Time     time.Time
+	ExitCode int
+	Signal   syscall.Signal
+	// Err describes why the job failed for EventFailed
+	Err error
+	// Attempt is the number of the attempt about to run for EventRetrying
+	Attempt int
+}
+
+// Watcher receives the events published after it subscribed. C is closed when the watcher is closed, Err reports
+// whether that was because the watcher fell behind.
+type Watcher struct {
+	C <-chan Event
+
+	c      chan Event
+	bus    *eventBus
+	filter func(Event) bool
+	err    error
+}
+
+// Close unsubscribes the watcher
+func (w *Watcher) Close() {
+	w.bus.unsubscribe(w, nil)
+}
+
+// Err returns ErrWatcherBehind if the watcher was closed because its buffer filled up
+func (w *Watcher) Err() error {
+	w.bus.mu.Lock()
+	defer w.bus.mu.Unlock()
+	return w.err
+}
+
+// eventBus fans out published events to every matching watcher. Publishing never blocks on a slow watcher.
+type eventBus struct {
+	mu       sync.Mutex
+	watchers map[*Watcher]struct{}
+}
+
+func newEventBus() *eventBus {
+	return &eventBus{watchers: make(map[*Watcher]struct{})}
+}
+
+func (b *eventBus) subscribe(filter func(Event) bool) *Watcher {
+	c := make(chan Event, watchBuffer)
+	w := &Watcher{C: c, c: c, bus: b, filter: filter}
+	b.mu.Lock()
+	b.watchers[w] = struct{}{}
+	b.mu.Unlock()
+	return w
+}
+
+func (b *eventBus) unsubscribe(w *Watcher, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.unsubscribeLocked(w, err)
+}
+
+func (b *eventBus) unsubscribeLocked(w *Watcher, err error) {
+	if _, ok := b.watchers[w]; !ok {
+		return
+	}
+	delete(b.watchers, w)
+	w.err = err
+	close(w.c)
+}
+
+func (b *eventBus) publish(event Event) {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for w := range b.watchers {
+		if w.filter != nil && !w.filter(event) {
+			continue
+		}
+		select {
+		case w.c <- event:
+		default:
+			b.unsubscribeLocked(w, ErrWatcherBehind)
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package jobs
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"job_runner/pkg/cgroupz"
+	"job_runner/pkg/jobs"
+)
+
+// ErrInvalidLimit is wrapped by the errors returned when a requested resource limit is out of bounds
+var ErrInvalidLimit = errors.New("invalid resource limit")
+
+// ErrOutputBudget is wrapped by the errors returned when the output a job may keep does not fit in the output budget
+var ErrOutputBudget = errors.New("output budget exhausted")
+
+// DefaultOutputBudget is the number of bytes of output all jobs together may keep by default
+const DefaultOutputBudget = 1 << 30
+
+// LimitConfig bounds the resource limits that can be requested for a job and provides the defaults
+// used when a limit is not requested.
+type LimitConfig struct {
+	MinCPUWeight     int
+	MaxCPUWeight     int
+	DefaultCPUWeight int
+
+	// memory in bytes
+	MinMemory     int64
+	MaxMemory     int64
+	DefaultMemory int64
+
+	// write iops on IODevice. A zero MaxIO allows any value and a zero DefaultIO leaves IO unlimited
+	MaxIO     int64
+	DefaultIO int64
+	// major and minor numbers of the block device io limits apply to
+	IODeviceMaj int
+	IODeviceMin int
+
+	// bytes of output kept per stream of each job. The limits apply to every job separately, the output of all jobs
+	// together is capped by ServiceConfig.OutputBudget. A zero MaxOutput allows any value and a zero DefaultOutput
+	// defaults to MaxOutput, jobs keep all their output if both are zero.
+	MaxOutput           int64
+	DefaultOutput       int64
+	DefaultOutputPolicy jobs.OutputPolicy
+
+	// time a job may run before it is stopped. A zero MaxTimeout allows any value and a zero DefaultTimeout
+	// defaults to MaxTimeout, jobs run until they end if both are zero
+	MaxTimeout     time.Duration
+	DefaultTimeout time.Duration
+}
+
+// DefaultLimitConfig returns the bounds enforced by cgroups v2 for cpu weight, a memory range of 4MiB to 4GiB
+// defaulting to 100MB, unlimited io on device 8:0 and up to 64MiB of output per stream defaulting to 1MiB.
+func DefaultLimitConfig() LimitConfig {
+	return LimitConfig{
+		MinCPUWeight:     1,
+		MaxCPUWeight:     10000,
+		DefaultCPUWeight: 100,
+		MinMemory:        4 << 20,
+		MaxMemory:        4 << 30,
+		DefaultMemory:    1e8,
+		IODeviceMaj:      8,
+		IODeviceMin:      0,
+
+		MaxOutput:           64 << 20,
+		DefaultOutput:       1 << 20,
+		DefaultOutputPolicy: jobs.OutputRing,
+	}
+}
+
+// Validate checks that the bounds are consistent and the defaults are within them
+func (c LimitConfig) Validate() error {
+	if c.MinCPUWeight < 1 || c.MaxCPUWeight > 10000 || c.MinCPUWeight > c.MaxCPUWeight {
+		return fmt.Errorf("cpu weight bounds %d-%d must be within 1-10000", c.MinCPUWeight, c.MaxCPUWeight)
+	}
+	if c.DefaultCPUWeight < c.MinCPUWeight || c.DefaultCPUWeight > c.MaxCPUWeight {
+		return fmt.Errorf("default cpu weight %d must be between %d and %d", c.DefaultCPUWeight, c.MinCPUWeight, c.MaxCPUWeight)
+	}
+	if c.MinMemory < 1 || c.MinMemory > c.MaxMemory {
+		return fmt.Errorf("memory bounds %d-%d must be positive and ordered", c.MinMemory, c.MaxMemory)
+	}
+	if c.DefaultMemory < c.MinMemory || c.DefaultMemory > c.MaxMemory {
+		return fmt.Errorf("default memory %d must be between %d and %d", c.DefaultMemory, c.MinMemory, c.MaxMemory)
+	}
+	if c.MaxIO < 0 || c.DefaultIO < 0 {
+		return errors.New("io limits must not be negative")
+	}
+	if c.MaxIO != 0 && c.DefaultIO > c.MaxIO {
+		return fmt.Errorf("default io %d must be at most %d", c.DefaultIO, c.MaxIO)
+	}
+	if c.MaxOutput < 0 || c.DefaultOutput < 0 {
+		return errors.New("output limits must not be negative")
+	}
+	if c.MaxOutput != 0 && c.DefaultOutput > c.MaxOutput {
+		return fmt.Errorf("default output %d must be at most %d", c.DefaultOutput, c.MaxOutput)
+	}
+	if err := validOutputPolicy(c.DefaultOutputPolicy); err != nil {
+		return err
+	}
+	if c.MaxTimeout < 0 || c.DefaultTimeout < 0 {
+		return errors.New("timeouts must not be negative")
+	}
+	if c.MaxTimeout != 0 && c.DefaultTimeout > c.MaxTimeout {
+		return fmt.Errorf("default timeout %s must be at most %s", c.DefaultTimeout, c.MaxTimeout)
+	}
+	return nil
+}
+
+// ResolveOutput validates the requested output limit and policy against the config and returns the values a job
+// runs with. A zero limit or an empty policy requests the default.
+func (c LimitConfig) ResolveOutput(limit int64, policy jobs.OutputPolicy) (int64, jobs.OutputPolicy, error) {
+	if limit < 0 {
+		return 0, "", fmt.Errorf("%w: output limit %d must not be negative", ErrInvalidLimit, limit)
+	}
+	if limit == 0 {
+		limit = c.DefaultOutput
+	}
+	if limit == 0 {
+		limit = c.MaxOutput
+	}
+	if c.MaxOutput != 0 && limit > c.MaxOutput {
+		return 0, "", fmt.Errorf("%w: output limit %d must be at most %d bytes", ErrInvalidLimit, limit, c.MaxOutput)
+	}
+
+	if policy == "" {
+		policy = c.DefaultOutputPolicy
+	}
+	if policy == "" {
+		policy = jobs.OutputRing
+	}
+	if err := validOutputPolicy(policy); err != nil {
+		return 0, "", err
+	}
+	return limit, policy, nil
+}
+
+// ResolveTimeout validates the requested timeout against the config and returns the time the job may run,
+// zero if it may run until it ends. A zero timeout requests the default.
+func (c LimitConfig) ResolveTimeout(timeout time.Duration) (time.Duration, error) {
+	if timeout < 0 {
+		return 0, fmt.Errorf("%w: timeout %s must not be negative", ErrInvalidLimit, timeout)
+	}
+	if timeout == 0 {
+		timeout = c.DefaultTimeout
+	}
+	if timeout == 0 {
+		timeout = c.MaxTimeout
+	}
+	if c.MaxTimeout != 0 && timeout > c.MaxTimeout {
+		return 0, fmt.Errorf("%w: timeout %s must be at most %s", ErrInvalidLimit, timeout, c.MaxTimeout)
+	}
+	return timeout, nil
+}
+
+// ParseOutputPolicy parses an output policy of ring, stop or kill
+func ParseOutputPolicy(s string) (jobs.OutputPolicy, error) {
+	policy := jobs.OutputPolicy(s)
+	if s == "" {
+		return "", fmt.Errorf("%w: empty output policy", ErrInvalidLimit)
+	}
+	if err := validOutputPolicy(policy); err != nil {
+		return "", err
+	}
+	return policy, nil
+}
+
+func validOutputPolicy(policy jobs.OutputPolicy) error {
+	switch policy {
+	case "", jobs.OutputRing, jobs.OutputStop, jobs.OutputKill:
+		return nil
+	}
+	return fmt.Errorf("%w: unknown output policy %q", ErrInvalidLimit, policy)
+}
+
+// Resolve validates the requested limits against the config and returns the resulting cgroup limits.
+// A zero value requests the default.
+func (c LimitConfig) Resolve(cpuWeight int64, memory int64, io int64) (cgroupz.ResourceLimit, error) {
+	limits := cgroupz.ResourceLimit{
+		CpuWeight: c.DefaultCPUWeight,
+		MaxMem:    int(c.DefaultMemory),
+	}
+
+	if cpuWeight != 0 {
+		if cpuWeight < int64(c.MinCPUWeight) || cpuWeight > int64(c.MaxCPUWeight) {
+			return cgroupz.ResourceLimit{}, fmt.Errorf("%w: cpu weight %d must be between %d and %d", ErrInvalidLimit, cpuWeight, c.MinCPUWeight, c.MaxCPUWeight)
+		}
+		limits.CpuWeight = int(cpuWeight)
+	}
+
+	if memory != 0 {
+		if memory < c.MinMemory || memory > c.MaxMemory {
+			return cgroupz.ResourceLimit{}, fmt.Errorf("%w: memory %d must be between %d and %d bytes", ErrInvalidLimit, memory, c.MinMemory, c.MaxMemory)
+		}
+		limits.MaxMem = int(memory)
+	}
+
+	if io < 0 {
+		return cgroupz.ResourceLimit{}, fmt.Errorf("%w: io %d must not be negative", ErrInvalidLimit, io)
+	}
+	if io == 0 {
+		io = c.DefaultIO
+	}
+	if c.MaxIO != 0 && io > c.MaxIO {
+		return cgroupz.ResourceLimit{}, fmt.Errorf("%w: io %d must be at most %d", ErrInvalidLimit, io, c.MaxIO)
+	}
+	if io != 0 {
+		limits.MaxIO = &cgroupz.IOLimit{
+			MaxIO: int(io),
+			Maj:   c.IODeviceMaj,
+			Min:   c.IODeviceMin,
+		}
+	}
+
+	return limits, nil
+}
+
+// outputReserved returns the number of bytes of output the job of record may keep: the output kept by its attempts
+// that have ended, and for each attempt that may still run its output limit for stdout and for stderr. An attempt
+// running without an output limit is counted with the output it keeps so far.
+func outputReserved(record JobRecord) int64 {
+	var reserved int64
+	for _, attempt := range record.Attempts {
+		if attempt.job != nil {
+			reserved += attempt.job.OutputSize()
+		}
+	}
+	limit := record.Job.Spec().OutputLimit
+	select {
+	case <-record.Job.Done():
+		return reserved + record.Job.OutputSize()
+	default:
+		if limit == 0 {
+			return reserved + record.Job.OutputSize()
+		}
+	}
+	remaining := int64(1)
+	if record.Retry.MaxAttempts > record.Attempt {
+		remaining = int64(record.Retry.MaxAttempts - record.Attempt + 1)
+	}
+	return reserved + remaining*2*limit
+}
@@ -0,0 +1,143 @@
+package jobs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"job_runner/pkg/cgroupz"
+	"job_runner/pkg/jobs"
+)
+
+func Test_LimitConfig_Resolve(t *testing.T) {
+	config := DefaultLimitConfig()
+	config.MaxIO = 1000
+	config.DefaultIO = 100
+
+	defaultIO := &cgroupz.IOLimit{MaxIO: 100, Maj: 8, Min: 0}
+	tests := []struct {
+		name      string
+		cpuWeight int64
+		memory    int64
+		io        int64
+		limits    cgroupz.ResourceLimit
+		err       bool
+	}{
+		{name: "defaults", limits: cgroupz.ResourceLimit{CpuWeight: 100, MaxMem: 1e8, MaxIO: defaultIO}},
+		{name: "requested", cpuWeight: 500, memory: 1 << 30, io: 1000, limits: cgroupz.ResourceLimit{CpuWeight: 500, MaxMem: 1 << 30, MaxIO: &cgroupz.IOLimit{MaxIO: 1000, Maj: 8}}},
+		{name: "bounds", cpuWeight: 1, memory: 4 << 20, limits: cgroupz.ResourceLimit{CpuWeight: 1, MaxMem: 4 << 20, MaxIO: defaultIO}},
+		{name: "cpu weight over max", cpuWeight: 10001, err: true},
+		{name: "negative cpu weight", cpuWeight: -1, err: true},
+		{name: "memory over max", memory: 4<<30 + 1, err: true},
+		{name: "memory under min", memory: 1 << 20, err: true},
+		{name: "negative memory", memory: -1, err: true},
+		{name: "io over max", io: 1001, err: true},
+		{name: "negative io", io: -1, err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limits, err := config.Resolve(tt.cpuWeight, tt.memory, tt.io)
+			if tt.err {
+				require.ErrorIs(t, err, ErrInvalidLimit)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.limits, limits)
+		})
+	}
+}
+
+func Test_LimitConfig_ResolveUnlimitedIO(t *testing.T) {
+	// without a default io is unlimited, and without a max any value is allowed
+	limits, err := DefaultLimitConfig().Resolve(0, 0, 0)
+	require.NoError(t, err)
+	require.Nil(t, limits.MaxIO)
+
+	limits, err = DefaultLimitConfig().Resolve(0, 0, 1e6)
+	require.NoError(t, err)
+	require.Equal(t, &cgroupz.IOLimit{MaxIO: 1e6, Maj: 8}, limits.MaxIO)
+}
+
+func Test_LimitConfig_ResolveTimeout(t *testing.T) {
+	tests := []struct {
+		name      string
+		max       time.Duration
+		defaultTo time.Duration
+		requested time.Duration
+		timeout   time.Duration
+		invalid   bool
+	}{
+		{name: "unlimited"},
+		{name: "requested without a max", requested: time.Hour, timeout: time.Hour},
+		{name: "default", max: time.Hour, defaultTo: time.Minute, timeout: time.Minute},
+		{name: "max without a default", max: time.Hour, timeout: time.Hour},
+		{name: "requested over default", max: time.Hour, defaultTo: time.Minute, requested: 30 * time.Minute, timeout: 30 * time.Minute},
+		{name: "requested max", max: time.Hour, requested: time.Hour, timeout: time.Hour},
+		{name: "over max", max: time.Hour, requested: time.Hour + time.Second, invalid: true},
+		{name: "negative", requested: -time.Second, invalid: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultLimitConfig()
+			config.MaxTimeout = tt.max
+			config.DefaultTimeout = tt.defaultTo
+			require.NoError(t, config.Validate())
+
+			timeout, err := config.ResolveTimeout(tt.requested)
+			if tt.invalid {
+				require.ErrorIs(t, err, ErrInvalidLimit)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.timeout, timeout)
+		})
+	}
+}
+
+func Test_LimitConfig_ResolveOutput(t *testing.T) {
+	// jobs keep a bounded amount of output unless the server lifts the limits
+	limit, _, err := DefaultLimitConfig().ResolveOutput(0, "")
+	require.NoError(t, err)
+	require.Equal(t, int64(1<<20), limit)
+	_, _, err = DefaultLimitConfig().ResolveOutput(1<<26+1, "")
+	require.ErrorIs(t, err, ErrInvalidLimit)
+
+	tests := []struct {
+		name      string
+		max       int64
+		defaultTo int64
+		requested int64
+		policy    jobs.OutputPolicy
+		limit     int64
+		resolved  jobs.OutputPolicy
+		invalid   bool
+	}{
+		{name: "unlimited by default", resolved: jobs.OutputRing},
+		{name: "requested without a max", requested: 1 << 40, limit: 1 << 40, resolved: jobs.OutputRing},
+		{name: "default", max: 1 << 30, defaultTo: 1 << 20, limit: 1 << 20, resolved: jobs.OutputRing},
+		{name: "max without a default", max: 1 << 30, limit: 1 << 30, resolved: jobs.OutputRing},
+		{name: "requested max", max: 1 << 30, requested: 1 << 30, limit: 1 << 30, resolved: jobs.OutputRing},
+		{name: "requested policy", requested: 1 << 10, policy: jobs.OutputKill, limit: 1 << 10, resolved: jobs.OutputKill},
+		{name: "over max", max: 1 << 30, requested: 1<<30 + 1, invalid: true},
+		{name: "negative", requested: -1, invalid: true},
+		{name: "unknown policy", policy: "drop", invalid: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultLimitConfig()
+			config.MaxOutput = tt.max
+			config.DefaultOutput = tt.defaultTo
+			require.NoError(t, config.Validate())
+
+			limit, policy, err := config.ResolveOutput(tt.requested, tt.policy)
+			if tt.invalid {
+				require.ErrorIs(t, err, ErrInvalidLimit)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.limit, limit)
+			require.Equal(t, tt.resolved, policy)
+		})
+	}
+}
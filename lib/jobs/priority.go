@@ -0,0 +1,152 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"syscall"
+	"time"
+
+	"job_runner/pkg/jobs"
+)
+
+// ErrJobEnded is returned when changing the priority of a job that has ended
+var ErrJobEnded = errors.New("job has ended")
+
+// defaultPreemptGrace is the time a preempted job has to exit after SIGTERM before it is killed
+const defaultPreemptGrace = 10 * time.Second
+
+// StartOptions controls how a job is scheduled
+type StartOptions struct {
+	// Priority orders the queue, queued jobs with a higher priority start first
+	Priority int32 `json:"priority,omitempty"`
+	// Preempt stops the running job with the lowest priority below Priority when the job is queued
+	Preempt bool `json:"preempt,omitempty"`
+	// RequeueOnPreempt queues the job again when it is preempted, otherwise it ends with StatusPreempted
+	RequeueOnPreempt bool `json:"requeue_on_preempt,omitempty"`
+	// Retry runs the job again in a new cgroup when it fails
+	Retry RetryPolicy `json:"retry"`
+	// Timeout stops each attempt once it has run this long and ends it with StatusTimedOut, time spent queued
+	// does not count. Zero lets the job run until it ends.
+	Timeout time.Duration `json:"timeout,omitempty"`
+}
+
+// enqueue inserts a job after the queued jobs with the same or a higher priority and returns its position.
+// The caller must hold the lock and the job must be in the records.
+func (s *Service) enqueue(id int32) int {
+	priority := s.records[id].Priority
+	i := sort.Search(len(s.queue), func(i int) bool { return s.records[s.queue[i]].Priority < priority })
+	s.queue = append(s.queue, 0)
+	copy(s.queue[i+1:], s.queue[i:])
+	s.queue[i] = id
+	return i + 1
+}
+
+// dequeue removes a job from the queue and reports whether it was queued. The caller must hold the lock.
+func (s *Service) dequeue(id int32) bool {
+	for i, queued := range s.queue {
+		if queued == id {
+			s.queue = append(s.queue[:i], s.queue[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// preempt stops the running job with the lowest priority below priority to make room for the queued job at
+// position, unless the jobs already being preempted make enough room. The most recently started job is
+// preempted among those with the same priority.
+func (s *Service) preempt(priority int32, position int) {
+	s.Lock()
+	if s.preempting >= position {
+		s.Unlock()
+		return
+	}
+	var victim JobRecord
+	for id, record := range s.records {
+		if _, ok := s.preempted[id]; ok || record.Priority >= priority {
+			continue
+		}
+		if record.Job.State().Status != jobs.StatusRunning {
+			continue
+		}
+		if victim.Job == nil || record.Priority < victim.Priority || (record.Priority == victim.Priority && record.ID > victim.ID) {
+			victim = record
+		}
+	}
+	if victim.Job == nil {
+		s.Unlock()
+		return
+	}
+	s.preempted[victim.ID] = true
+	s.preempting++
+	s.Unlock()
+
+	s.events.publish(Event{Type: EventPreempted, JobID: victim.ID, Owner: victim.Owner})
+	go func() {
+		if err := victim.Job.Preempt(syscall.SIGTERM, s.preemptGrace); err != nil {
+			fmt.Printf("error preempting job with id %d: %v\n", victim.ID, err)
+		}
+	}()
+}
+
+// requeue queues a preempted job again as a new run of the same command, the output of the preempted run
+// is removed
+func (s *Service) requeue(record JobRecord) error {
+	spec := record.Job.Spec()
+	if spec.OutputDir != "" {
+		if err := os.RemoveAll(spec.OutputDir); err != nil {
+			return fmt.Errorf("os.RemoveAll: %w", err)
+		}
+	}
+	requeued := s.newRecord(record.ID, record.Owner, spec, record.StartOptions, record.Attempt)
+	requeued.Attempts = record.Attempts
+	requeued.Job.Queue()
+
+	s.Lock()
+	current, ok := s.records[record.ID]
+	if !ok {
+		s.Unlock()
+		requeued.cancel()
+		return ErrJobNotFound
+	}
+	// the priority may have been updated while the job was running
+	requeued.StartOptions = current.StartOptions
+	s.records[record.ID] = requeued
+	requeued.QueuePosition = s.enqueue(record.ID)
+	s.Unlock()
+	s.save(requeued)
+	s.events.publish(Event{Type: EventQueued, JobID: record.ID, Owner: record.Owner})
+	return nil
+}
+
+// UpdatePriority changes the priority of a job that has not ended. A queued job moves in the queue and may
+// preempt running jobs if it was started with Preempt.
+func (s *Service) UpdatePriority(ctx context.Context, jobID int32, priority int32) (JobRecord, error) {
+	s.Lock()
+	record, ok := s.records[jobID]
+	if !ok {
+		s.Unlock()
+		return JobRecord{}, ErrJobNotFound
+	}
+	select {
+	case <-record.Job.Done():
+		s.Unlock()
+		return JobRecord{}, ErrJobEnded
+	default:
+	}
+	record.Priority = priority
+	s.records[jobID] = record
+	if s.dequeue(jobID) {
+		record.QueuePosition = s.enqueue(jobID)
+	}
+	s.Unlock()
+
+	s.save(record)
+	if record.QueuePosition > 0 && record.Preempt {
+		s.preempt(record.Priority, record.QueuePosition)
+	}
+	return record, nil
+}
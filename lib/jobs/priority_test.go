@@ -0,0 +1,150 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"job_runner/pkg/jobs"
+)
+
+func Test_Service_PreemptLowestPriority(t *testing.T) {
+	tests := []struct {
+		name       string
+		priorities []int32
+		victim     int
+	}{
+		{name: "lowest priority", priorities: []int32{1, 0, 2}, victim: 1},
+		{name: "most recently started among the lowest", priorities: []int32{0, 0, 1}, victim: 1},
+		{name: "negative priority", priorities: []int32{-1, 3, 0}, victim: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService(t, ServiceConfig{MaxRunning: len(tt.priorities), PreemptGrace: time.Second})
+			var running []JobRecord
+			for _, priority := range tt.priorities {
+				record := startTestJob(t, s, StartOptions{Priority: priority}, "sleep", "5")
+				waitStatus(t, s, record.ID, jobs.StatusRunning)
+				running = append(running, record)
+			}
+			w, err := s.Watch(context.Background(), 0, "")
+			require.NoError(t, err)
+			defer w.Close()
+
+			preempting := startTestJob(t, s, StartOptions{Priority: 5, Preempt: true}, "true")
+			require.Equal(t, 1, preempting.QueuePosition)
+
+			victim := running[tt.victim]
+			require.Equal(t, victim.ID, nextEvent(t, w, EventPreempted).JobID)
+			require.Equal(t, preempting.ID, nextEvent(t, w, EventStarted).JobID)
+			waitStatus(t, s, victim.ID, jobs.StatusPreempted)
+			for i, record := range running {
+				if i != tt.victim {
+					require.Equal(t, jobs.StatusRunning, jobStatus(t, s, record.ID))
+				}
+			}
+		})
+	}
+}
+
+func Test_Service_PreemptNothingLower(t *testing.T) {
+	s := newTestService(t, ServiceConfig{MaxRunning: 1, PreemptGrace: time.Second})
+	running := startTestJob(t, s, StartOptions{Priority: 5}, "sleep", "5")
+	waitStatus(t, s, running.ID, jobs.StatusRunning)
+
+	// a job only preempts jobs with a lower priority
+	queued := startTestJob(t, s, StartOptions{Priority: 5, Preempt: true}, "true")
+	require.Equal(t, 1, queued.QueuePosition)
+	time.Sleep(100 * time.Millisecond)
+	require.Equal(t, jobs.StatusRunning, jobStatus(t, s, running.ID))
+	require.Equal(t, jobs.StatusQueued, jobStatus(t, s, queued.ID))
+}
+
+func Test_Service_PreemptRequeue(t *testing.T) {
+	s := newTestService(t, ServiceConfig{MaxRunning: 1, PreemptGrace: time.Second})
+	w, err := s.Watch(context.Background(), 0, "")
+	require.NoError(t, err)
+	defer w.Close()
+
+	victim := startTestJob(t, s, StartOptions{RequeueOnPreempt: true}, "sleep", "5")
+	require.Equal(t, victim.ID, nextEvent(t, w, EventStarted).JobID)
+	preempting := startTestJob(t, s, StartOptions{Priority: 5, Preempt: true}, "true")
+
+	require.Equal(t, victim.ID, nextEvent(t, w, EventPreempted).JobID)
+	queued := nextEvent(t, w, EventQueued, EventStarted)
+	require.Equal(t, EventQueued, queued.Type)
+	require.Equal(t, victim.ID, queued.JobID)
+	require.Equal(t, preempting.ID, nextEvent(t, w, EventStarted).JobID)
+	require.Equal(t, preempting.ID, nextEvent(t, w, EventExited).JobID)
+
+	// the preempted job runs again as a new run of the same command once the preempting job has ended
+	require.Equal(t, victim.ID, nextEvent(t, w, EventStarted).JobID)
+	record, err := s.GetJob(context.Background(), victim.ID)
+	require.NoError(t, err)
+	require.NotSame(t, victim.Job, record.Job)
+	require.Equal(t, jobs.StatusRunning, record.Job.State().Status)
+
+	// waiting on the job waits for the new run
+	stopTestJob(t, s, victim.ID)
+	waited, err := s.WaitJob(context.Background(), victim.ID)
+	require.NoError(t, err)
+	require.Same(t, record.Job, waited.Job)
+}
+
+func Test_Service_UpdatePriorityQueueOrder(t *testing.T) {
+	s := newTestService(t, ServiceConfig{MaxRunning: 1})
+	w, err := s.Watch(context.Background(), 0, "")
+	require.NoError(t, err)
+	defer w.Close()
+
+	running := startTestJob(t, s, StartOptions{}, "sleep", "5")
+	first := startTestJob(t, s, StartOptions{}, "true")
+	second := startTestJob(t, s, StartOptions{}, "true")
+	third := startTestJob(t, s, StartOptions{}, "true")
+
+	record, err := s.UpdatePriority(context.Background(), third.ID, 5)
+	require.NoError(t, err)
+	require.Equal(t, 1, record.QueuePosition)
+	record, err = s.UpdatePriority(context.Background(), first.ID, -1)
+	require.NoError(t, err)
+	require.Equal(t, 3, record.QueuePosition)
+
+	require.Equal(t, running.ID, nextEvent(t, w, EventStarted).JobID)
+	stopTestJob(t, s, running.ID)
+	for _, id := range []int32{third.ID, second.ID, first.ID} {
+		require.Equal(t, id, nextEvent(t, w, EventStarted).JobID)
+	}
+
+	_, err = s.WaitJob(context.Background(), first.ID)
+	require.NoError(t, err)
+	_, err = s.UpdatePriority(context.Background(), first.ID, 1)
+	require.ErrorIs(t, err, ErrJobEnded)
+}
+
+func Test_Service_RequeueKeepsUpdatedPriority(t *testing.T) {
+	s := newTestService(t, ServiceConfig{MaxRunning: 1, PreemptGrace: time.Second})
+	w, err := s.Watch(context.Background(), 0, "")
+	require.NoError(t, err)
+	defer w.Close()
+
+	victim := startTestJob(t, s, StartOptions{RequeueOnPreempt: true}, "sleep", "5")
+	require.Equal(t, victim.ID, nextEvent(t, w, EventStarted).JobID)
+	// raised while running, the job is queued again with the raised priority
+	_, err = s.UpdatePriority(context.Background(), victim.ID, 3)
+	require.NoError(t, err)
+	waiting := startTestJob(t, s, StartOptions{Priority: 2}, "true")
+	require.Equal(t, 1, waiting.QueuePosition)
+
+	preempting := startTestJob(t, s, StartOptions{Priority: 5, Preempt: true}, "true")
+	require.Equal(t, 1, preempting.QueuePosition)
+	require.Equal(t, victim.ID, nextEvent(t, w, EventPreempted).JobID)
+	require.Equal(t, preempting.ID, nextEvent(t, w, EventStarted).JobID)
+	require.Equal(t, victim.ID, nextEvent(t, w, EventStarted).JobID)
+
+	record, err := s.GetJob(context.Background(), victim.ID)
+	require.NoError(t, err)
+	require.Equal(t, int32(3), record.Priority)
+	require.Equal(t, jobs.StatusQueued, jobStatus(t, s, waiting.ID))
+}
@@ -0,0 +1,168 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"job_runner/pkg/jobs"
+)
+
+// ErrJobRunning is returned when removing a job that has not ended
+var ErrJobRunning = errors.New("job is running")
+
+// ErrJobQueued is returned when removing a job that is queued or waiting to be retried
+var ErrJobQueued = errors.New("job is queued")
+
+// collectInterval is how often the retention policy is applied
+const collectInterval = time.Minute
+
+// RetentionPolicy selects the jobs that have ended that a Service removes. Zero values keep every job.
+type RetentionPolicy struct {
+	// MaxAge removes jobs that ended more than MaxAge ago
+	MaxAge time.Duration
+	// MaxCount removes the jobs that ended first once more than MaxCount jobs have ended
+	MaxCount int
+	// KeepSummary removes only the output of a job and keeps how it ran, so it can still be listed and fetched.
+	// Jobs that only have their summary left are not counted towards MaxCount.
+	KeepSummary bool
+}
+
+func (p RetentionPolicy) enabled() bool {
+	return p.MaxAge > 0 || p.MaxCount > 0
+}
+
+// collectLoop applies the retention policy on start and every collectInterval until the service shuts down
+func (s *Service) collectLoop() {
+	defer s.wg.Done()
+	ticker := time.NewTicker(collectInterval)
+	defer ticker.Stop()
+	for {
+		s.collect(time.Now())
+		select {
+		case <-s.parentCtx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// collect removes the jobs the retention policy no longer keeps at now
+func (s *Service) collect(now time.Time) {
+	type ended struct {
+		record JobRecord
+		at     time.Time
+	}
+	var candidates []ended
+	s.Lock()
+	for _, record := range s.records {
+		if record.Summary {
+			continue
+		}
+		select {
+		case <-record.Job.Done():
+			candidates = append(candidates, ended{record: record, at: record.Job.State().EndedAt})
+		default:
+		}
+	}
+	s.Unlock()
+	// newest first, so the jobs past MaxCount are at the end
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].at.After(candidates[j].at) })
+
+	for i, candidate := range candidates {
+		expired := s.retention.MaxAge > 0 && now.Sub(candidate.at) > s.retention.MaxAge
+		excess := s.retention.MaxCount > 0 && i >= s.retention.MaxCount
+		if !expired && !excess {
+			continue
+		}
+		if err := s.remove(candidate.record, s.retention.KeepSummary); err != nil {
+			fmt.Printf("error removing job with id %d: %v\n", candidate.record.ID, err)
+		}
+	}
+}
+
+// DeleteJob removes a job that has ended along with its output. With keepSummary, only the output is removed.
+// A job that has not ended must be stopped first, which cancels a queued job.
+func (s *Service) DeleteJob(ctx context.Context, jobID int32, keepSummary bool) error {
+	record, err := s.GetJob(ctx, jobID)
+	if err != nil {
+		return err
+	}
+	select {
+	case <-record.Job.Done():
+	default:
+		if record.Job.State().Status == jobs.StatusQueued {
+			return ErrJobQueued
+		}
+		return ErrJobRunning
+	}
+	if keepSummary && record.Summary {
+		return nil
+	}
+	return s.remove(record, keepSummary)
+}
+
+// remove removes the job of record and its output, or replaces it with its summary if keepSummary is set
+func (s *Service) remove(record JobRecord, keepSummary bool) error {
+	spec := record.Job.Spec()
+	// the output of every attempt is kept under the output directory of the first
+	outputDir := spec.OutputDir
+	if len(record.Attempts) > 0 {
+		outputDir = record.Attempts[0].OutputDir
+	}
+
+	if keepSummary {
+		spec.OutputDir = ""
+		summary := record
+		summary.Job = jobs.Restore(spec, record.Job.State())
+		summary.Summary = true
+		// earlier attempts keep how they ran but not their output
+		summary.Attempts = make([]Attempt, 0, len(record.Attempts))
+		for _, attempt := range record.Attempts {
+			attempt.OutputDir = ""
+			attempt.job = jobs.Restore(spec, attempt.state())
+			summary.Attempts = append(summary.Attempts, attempt)
+		}
+		if err := s.replace(record.ID, summary); err != nil {
+			return err
+		}
+		if err := s.store.Save(s.storedJob(summary)); err != nil {
+			return fmt.Errorf("store.Save: %w", err)
+		}
+	} else {
+		if err := s.replace(record.ID, JobRecord{}); err != nil {
+			return err
+		}
+		if err := s.store.Delete(record.ID); err != nil {
+			return fmt.Errorf("store.Delete: %w", err)
+		}
+		s.events.publish(Event{Type: EventRemoved, JobID: record.ID, Owner: record.Owner, Time: time.Now()})
+	}
+
+	if outputDir != "" {
+		if err := os.RemoveAll(outputDir); err != nil {
+			return fmt.Errorf("os.RemoveAll: %w", err)
+		}
+	}
+	return nil
+}
+
+// replace swaps the record of a job for record, or deletes it if record has no job
+func (s *Service) replace(jobID int32, record JobRecord) error {
+	s.Lock()
+	defer s.Unlock()
+	if _, ok := s.records[jobID]; !ok {
+		// removed concurrently
+		return ErrJobNotFound
+	}
+	if record.Job == nil {
+		delete(s.records, jobID)
+		delete(s.transitions, jobID)
+	} else {
+		s.records[jobID] = record
+	}
+	return nil
+}
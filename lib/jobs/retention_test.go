@@ -0,0 +1,148 @@
+package jobs
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"job_runner/pkg/jobs"
+)
+
+// endedAtStore returns a store holding a job for each time in ended, which ended then, with ids starting at 1
+func endedAtStore(t *testing.T, ended ...time.Time) *MemoryStore {
+	store := NewMemoryStore()
+	for i, at := range ended {
+		job := storedJob(int32(i+1), jobs.StatusExited)
+		job.StartedAt = at.Add(-time.Second)
+		job.EndedAt = at
+		require.NoError(t, store.Save(job))
+	}
+	return store
+}
+
+func storedIDs(t *testing.T, store Store) []int32 {
+	stored, err := store.List()
+	require.NoError(t, err)
+	var ids []int32
+	for _, job := range stored {
+		ids = append(ids, job.ID)
+	}
+	return ids
+}
+
+func Test_Service_Collect(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name      string
+		policy    RetentionPolicy
+		remaining []int32
+		summaries []int32
+	}{
+		{name: "max age", policy: RetentionPolicy{MaxAge: time.Hour}, remaining: []int32{3, 4}},
+		{name: "max count", policy: RetentionPolicy{MaxCount: 3}, remaining: []int32{2, 3, 4}},
+		{name: "max age and count", policy: RetentionPolicy{MaxAge: time.Hour, MaxCount: 1}, remaining: []int32{4}},
+		{name: "keep summary", policy: RetentionPolicy{MaxCount: 1, KeepSummary: true}, remaining: []int32{1, 2, 3, 4}, summaries: []int32{1, 2, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the jobs are stored out of the order they ended in
+			store := endedAtStore(t, now.Add(-3*time.Hour), now.Add(-2*time.Hour), now.Add(-time.Minute), now.Add(-time.Second))
+			s := newTestService(t, ServiceConfig{Store: store})
+			running := startTestJob(t, s, StartOptions{}, "sleep", "5")
+			s.retention = tt.policy
+
+			s.collect(now)
+			// jobs that have not ended are kept
+			require.Equal(t, append(tt.remaining, running.ID), storedIDs(t, store))
+			var summaries []int32
+			for _, id := range tt.remaining {
+				record, err := s.GetJob(context.Background(), id)
+				require.NoError(t, err)
+				if record.Summary {
+					summaries = append(summaries, id)
+				}
+			}
+			require.Equal(t, tt.summaries, summaries)
+
+			// summaries are not counted towards MaxCount, so collecting again removes nothing more
+			s.collect(now)
+			require.Equal(t, append(tt.remaining, running.ID), storedIDs(t, store))
+		})
+	}
+}
+
+func Test_Service_DeleteJob(t *testing.T) {
+	s := newTestService(t, ServiceConfig{Store: endedAtStore(t, time.Now()), MaxRunning: 1})
+	running := startTestJob(t, s, StartOptions{}, "sleep", "5")
+	queued := startTestJob(t, s, StartOptions{}, "true")
+
+	require.ErrorIs(t, s.DeleteJob(context.Background(), running.ID, false), ErrJobRunning)
+	require.ErrorIs(t, s.DeleteJob(context.Background(), queued.ID, false), ErrJobQueued)
+
+	require.NoError(t, s.DeleteJob(context.Background(), 1, true))
+	require.NoError(t, s.DeleteJob(context.Background(), 1, true))
+	record, err := s.GetJob(context.Background(), 1)
+	require.NoError(t, err)
+	require.True(t, record.Summary)
+
+	require.NoError(t, s.DeleteJob(context.Background(), 1, false))
+	require.ErrorIs(t, s.DeleteJob(context.Background(), 1, false), ErrJobNotFound)
+}
+
+func Test_Service_DeleteJobRetrying(t *testing.T) {
+	s := newTestService(t, ServiceConfig{})
+	w, err := s.Watch(context.Background(), 0, "")
+	require.NoError(t, err)
+	defer w.Close()
+
+	policy := RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Minute}
+	record := startTestJob(t, s, StartOptions{Retry: policy}, "false")
+	nextEvent(t, w, EventRetrying)
+	require.ErrorIs(t, s.DeleteJob(context.Background(), record.ID, false), ErrJobQueued)
+}
+
+func streamAttempt(t *testing.T, s *Service, id int32, attempt int) string {
+	record, err := s.GetJob(context.Background(), id)
+	require.NoError(t, err)
+	job, err := record.attemptJob(attempt)
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, job.Stream(context.Background(), &buf))
+	return buf.String()
+}
+
+func Test_Service_DeleteJobKeepSummary(t *testing.T) {
+	outputDir := t.TempDir()
+	s := newTestService(t, ServiceConfig{OutputDir: outputDir})
+	policy := RetryPolicy{MaxAttempts: 2, InitialBackoff: 10 * time.Millisecond}
+	record := startTestJob(t, s, StartOptions{Retry: policy}, "sh", "-c", "echo out; exit 1")
+	ended, err := s.WaitJob(context.Background(), record.ID)
+	require.NoError(t, err)
+	require.Equal(t, 2, ended.Attempt)
+	require.Equal(t, "out\n", streamAttempt(t, s, record.ID, 1))
+	require.Equal(t, "out\n", streamAttempt(t, s, record.ID, 2))
+	_, err = os.Stat(filepath.Join(outputDir, "1", "attempts", "2"))
+	require.NoError(t, err)
+
+	require.NoError(t, s.DeleteJob(context.Background(), record.ID, true))
+	summary, err := s.GetJob(context.Background(), record.ID)
+	require.NoError(t, err)
+	require.True(t, summary.Summary)
+	require.Equal(t, ended.Job.State(), summary.Job.State())
+	require.Empty(t, summary.Job.Spec().OutputDir)
+	require.Len(t, summary.Attempts, 1)
+	require.Equal(t, ended.Attempts[0].state(), summary.Attempts[0].state())
+	require.Empty(t, summary.Attempts[0].OutputDir)
+
+	// the output of every attempt is removed, along with the jobs holding it
+	require.Empty(t, streamAttempt(t, s, record.ID, 1))
+	require.Empty(t, streamAttempt(t, s, record.ID, 2))
+	require.NotSame(t, ended.Attempts[0].job, summary.Attempts[0].job)
+	_, err = os.Stat(filepath.Join(outputDir, "1"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
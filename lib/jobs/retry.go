@@ -0,0 +1,252 @@
+package jobs
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"time"
+
+	"job_runner/pkg/jobs"
+)
+
+// ErrAttemptNotFound is returned when streaming an attempt a job has not made
+var ErrAttemptNotFound = errors.New("attempt not found")
+
+const (
+	// maxAttempts caps RetryPolicy.MaxAttempts
+	maxAttempts = 100
+	// defaultRetryBackoff is the delay before the second attempt of a job, unless its policy sets one
+	defaultRetryBackoff = time.Second
+	// defaultMaxRetryBackoff caps the delay between attempts, unless the policy sets a cap
+	defaultMaxRetryBackoff = 5 * time.Minute
+)
+
+// RetryPolicy runs a job again when it fails. The delay before each attempt doubles from InitialBackoff up to
+// MaxBackoff, and a random jitter of up to half the delay is taken off so retries of jobs that failed together
+// spread out.
+type RetryPolicy struct {
+	// MaxAttempts is the number of times the job runs at most, including the first. Jobs run once if it is below 2.
+	MaxAttempts int `json:"max_attempts,omitempty"`
+	// ExitCodes are the non-zero exit codes that are retried
+	ExitCodes []int `json:"exit_codes,omitempty"`
+	// Signals are the signals stopping the job that are retried. If neither ExitCodes nor Signals are set,
+	// every non-zero exit code and every signal is retried.
+	Signals []syscall.Signal `json:"signals,omitempty"`
+	// InitialBackoff defaults to 1s
+	InitialBackoff time.Duration `json:"initial_backoff,omitempty"`
+	// MaxBackoff defaults to 5m
+	MaxBackoff time.Duration `json:"max_backoff,omitempty"`
+}
+
+func (p RetryPolicy) validate() error {
+	if p.MaxAttempts < 0 || p.MaxAttempts > maxAttempts {
+		return fmt.Errorf("max attempts must be between 0 and %d", maxAttempts)
+	}
+	for _, code := range p.ExitCodes {
+		if code < 1 || code > 255 {
+			return fmt.Errorf("retried exit code %d must be between 1 and 255", code)
+		}
+	}
+	if p.InitialBackoff < 0 || p.MaxBackoff < 0 {
+		return errors.New("backoff must not be negative")
+	}
+	if p.MaxBackoff > 0 && p.InitialBackoff > p.MaxBackoff {
+		return errors.New("initial backoff must not exceed max backoff")
+	}
+	return nil
+}
+
+// retryable reports whether an attempt that ended in state is retried. Jobs stopped through the service,
+// preempted, timed out, or that could not be started are not. Jobs killed by the OOM killer are retried like jobs
+// stopped by SIGKILL.
+func (p RetryPolicy) retryable(state jobs.State) bool {
+	retryAll := len(p.ExitCodes) == 0 && len(p.Signals) == 0
+	switch state.Status {
+	case jobs.StatusExited:
+		if state.ExitCode == 0 {
+			return false
+		}
+		for _, code := range p.ExitCodes {
+			if code == state.ExitCode {
+				return true
+			}
+		}
+		return retryAll
+	case jobs.StatusStopped, jobs.StatusOOMKilled:
+		for _, sig := range p.Signals {
+			if sig == state.Signal {
+				return true
+			}
+		}
+		return retryAll
+	}
+	return false
+}
+
+// backoff returns the delay before the attempt following attempt
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay, max := p.InitialBackoff, p.MaxBackoff
+	if delay <= 0 {
+		delay = defaultRetryBackoff
+	}
+	if max <= 0 {
+		max = defaultMaxRetryBackoff
+	}
+	for i := 1; i < attempt && delay < max; i++ {
+		delay *= 2
+	}
+	if delay > max {
+		delay = max
+	}
+	return delay - time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// Attempt is a run of a job that has ended and was retried
+type Attempt struct {
+	Number    int            `json:"number"`
+	Status    jobs.Status    `json:"status"`
+	ExitCode  int            `json:"exit_code"`
+	Signal    syscall.Signal `json:"signal,omitempty"`
+	StartedAt time.Time      `json:"started_at"`
+	EndedAt   time.Time      `json:"ended_at"`
+
+	OutputTruncated int64 `json:"output_truncated,omitempty"`
+	// OutputDir is where the output of the attempt is kept, empty when output is kept in memory
+	OutputDir string `json:"output_dir,omitempty"`
+
+	// job holds the output of the attempt
+	job *jobs.Job
+}
+
+func (a Attempt) state() jobs.State {
+	return jobs.State{
+		Status:    a.Status,
+		ExitCode:  a.ExitCode,
+		Signal:    a.Signal,
+		StartedAt: a.StartedAt,
+		EndedAt:   a.EndedAt,
+
+		OutputTruncated: a.OutputTruncated,
+	}
+}
+
+// endedAttempt records the current attempt of record, which has ended
+func endedAttempt(record JobRecord) Attempt {
+	state := record.Job.State()
+	return Attempt{
+		Number:    record.Attempt,
+		Status:    state.Status,
+		ExitCode:  state.ExitCode,
+		Signal:    state.Signal,
+		StartedAt: state.StartedAt,
+		EndedAt:   state.EndedAt,
+
+		OutputTruncated: state.OutputTruncated,
+		OutputDir:       record.Job.Spec().OutputDir,
+		job:             record.Job,
+	}
+}
+
+// restoreAttempts returns the attempts of a stored job along with jobs holding their output
+func restoreAttempts(stored StoredJob) []Attempt {
+	attempts := make([]Attempt, 0, len(stored.Attempts))
+	for _, attempt := range stored.Attempts {
+		spec := stored.Spec
+		spec.OutputDir = attempt.OutputDir
+		attempt.job = jobs.Restore(spec, attempt.state())
+		attempts = append(attempts, attempt)
+	}
+	return attempts
+}
+
+// attemptOutputDir returns the output directory of an attempt of the job with id, empty if output is kept in memory.
+// Attempts after the first keep their output in a subdirectory of the first attempt's, so removing the job's output
+// removes the output of every attempt.
+func (s *Service) attemptOutputDir(id int32, attempt int) string {
+	if s.outputDir == "" {
+		return ""
+	}
+	dir := filepath.Join(s.outputDir, strconv.Itoa(int(id)))
+	if attempt > 1 {
+		dir = filepath.Join(dir, "attempts", strconv.Itoa(attempt))
+	}
+	return dir
+}
+
+// retry schedules the next attempt of the job of record, which has ended, and reports whether it did.
+// The next attempt is admitted like a new job once its backoff has passed, until then it is shown as queued.
+func (s *Service) retry(record JobRecord, stopped bool) bool {
+	policy := record.Retry
+	if stopped || s.parentCtx.Err() != nil || record.Attempt >= policy.MaxAttempts || !policy.retryable(record.Job.State()) {
+		return false
+	}
+
+	next := s.newRecord(record.ID, record.Owner, record.Job.Spec(), record.StartOptions, record.Attempt+1)
+	next.Attempts = append(append([]Attempt(nil), record.Attempts...), endedAttempt(record))
+	next.settled = record.settled
+	next.RetryAt = time.Now().Add(policy.backoff(record.Attempt))
+	next.Job.Queue()
+
+	s.Lock()
+	current, ok := s.records[record.ID]
+	if !ok {
+		s.Unlock()
+		next.cancel()
+		return false
+	}
+	// the priority may have been updated while the job was running
+	next.StartOptions = current.StartOptions
+	s.records[record.ID] = next
+	s.armRetry(next)
+	s.Unlock()
+
+	s.save(next)
+	s.events.publish(Event{Type: EventRetrying, JobID: record.ID, Owner: record.Owner, Time: next.RetryAt, Attempt: next.Attempt})
+	return true
+}
+
+// armRetry admits the attempt of next once its backoff has passed at next.RetryAt, the caller must hold the lock
+func (s *Service) armRetry(next JobRecord) {
+	s.retrying[next.ID] = time.AfterFunc(time.Until(next.RetryAt), func() {
+		s.Lock()
+		_, ok := s.retrying[next.ID]
+		delete(s.retrying, next.ID)
+		current, exists := s.records[next.ID]
+		// the job may have been removed while it waited, its waiters are released
+		removed := ok && (!exists || current.Job != next.Job)
+		ok = ok && !removed
+		if ok {
+			current.RetryAt = time.Time{}
+			s.records[next.ID] = current
+		}
+		s.Unlock()
+		if removed {
+			next.cancel()
+			close(next.settled)
+			return
+		}
+		// the attempt was cancelled while waiting, or the service is shutting down and it is retried on the next start
+		if !ok || s.parentCtx.Err() != nil {
+			return
+		}
+		if _, err := s.admit(current); err != nil {
+			fmt.Printf("error retrying job with id %d: %v\n", next.ID, err)
+		}
+	})
+}
+
+// attemptJob returns the job of the given attempt of record, 0 is the current attempt
+func (r JobRecord) attemptJob(attempt int) (*jobs.Job, error) {
+	if attempt == 0 || attempt == r.Attempt {
+		return r.Job, nil
+	}
+	for _, a := range r.Attempts {
+		if a.Number == attempt {
+			return a.job, nil
+		}
+	}
+	return nil, ErrAttemptNotFound
+}
@@ -0,0 +1,214 @@
+package jobs
+
+import (
+	"context"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"job_runner/pkg/jobs"
+)
+
+func Test_RetryPolicy_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy RetryPolicy
+		ok     bool
+	}{
+		{name: "zero", ok: true},
+		{name: "full", policy: RetryPolicy{MaxAttempts: 5, ExitCodes: []int{1, 255}, Signals: []syscall.Signal{syscall.SIGKILL}, InitialBackoff: time.Second, MaxBackoff: time.Minute}, ok: true},
+		{name: "max attempts", policy: RetryPolicy{MaxAttempts: maxAttempts}, ok: true},
+		{name: "too many attempts", policy: RetryPolicy{MaxAttempts: maxAttempts + 1}},
+		{name: "negative attempts", policy: RetryPolicy{MaxAttempts: -1}},
+		{name: "exit code 0", policy: RetryPolicy{ExitCodes: []int{0}}},
+		{name: "exit code out of range", policy: RetryPolicy{ExitCodes: []int{256}}},
+		{name: "negative backoff", policy: RetryPolicy{InitialBackoff: -time.Second}},
+		{name: "negative max backoff", policy: RetryPolicy{MaxBackoff: -time.Second}},
+		{name: "initial over max", policy: RetryPolicy{InitialBackoff: time.Minute, MaxBackoff: time.Second}},
+		{name: "initial over default max", policy: RetryPolicy{InitialBackoff: time.Hour}, ok: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.validate()
+			if tt.ok {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
+func Test_RetryPolicy_Retryable(t *testing.T) {
+	exited := func(code int) jobs.State { return jobs.State{Status: jobs.StatusExited, ExitCode: code} }
+	stopped := func(sig syscall.Signal) jobs.State { return jobs.State{Status: jobs.StatusStopped, Signal: sig} }
+	oomKilled := jobs.State{Status: jobs.StatusOOMKilled, Signal: syscall.SIGKILL}
+
+	codes := RetryPolicy{ExitCodes: []int{2, 3}}
+	signals := RetryPolicy{Signals: []syscall.Signal{syscall.SIGKILL}}
+	tests := []struct {
+		name      string
+		policy    RetryPolicy
+		state     jobs.State
+		retryable bool
+	}{
+		{name: "success", state: exited(0)},
+		{name: "retry all exit codes", state: exited(1), retryable: true},
+		{name: "retry all signals", state: stopped(syscall.SIGSEGV), retryable: true},
+		{name: "listed exit code", policy: codes, state: exited(3), retryable: true},
+		{name: "unlisted exit code", policy: codes, state: exited(1)},
+		// listing exit codes only retries those, no signal is retried
+		{name: "exit codes do not retry signals", policy: codes, state: stopped(syscall.SIGKILL)},
+		{name: "listed signal", policy: signals, state: stopped(syscall.SIGKILL), retryable: true},
+		{name: "unlisted signal", policy: signals, state: stopped(syscall.SIGTERM)},
+		{name: "signals do not retry exit codes", policy: signals, state: exited(1)},
+		{name: "oom killed like SIGKILL", policy: signals, state: oomKilled, retryable: true},
+		{name: "oom killed with retry all", state: oomKilled, retryable: true},
+		{name: "oom killed without SIGKILL", policy: RetryPolicy{Signals: []syscall.Signal{syscall.SIGTERM}}, state: oomKilled},
+		{name: "preempted", state: jobs.State{Status: jobs.StatusPreempted, Signal: syscall.SIGTERM}},
+		{name: "timed out", state: jobs.State{Status: jobs.StatusTimedOut, Signal: syscall.SIGTERM}},
+		{name: "output limit", state: jobs.State{Status: jobs.StatusOutputLimit, Signal: syscall.SIGKILL}},
+		{name: "failed to start", state: jobs.State{Status: jobs.StatusUnknown}},
+		{name: "cancelled", state: jobs.State{Status: jobs.StatusCancelled}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.retryable, tt.policy.retryable(tt.state))
+		})
+	}
+}
+
+func Test_RetryPolicy_Backoff(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  RetryPolicy
+		attempt int
+		delay   time.Duration
+	}{
+		{name: "default", attempt: 1, delay: defaultRetryBackoff},
+		{name: "doubles", attempt: 3, delay: 4 * defaultRetryBackoff},
+		{name: "default cap", attempt: 50, delay: defaultMaxRetryBackoff},
+		{name: "initial", policy: RetryPolicy{InitialBackoff: 100 * time.Millisecond}, attempt: 1, delay: 100 * time.Millisecond},
+		{name: "capped", policy: RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second}, attempt: 4, delay: 5 * time.Second},
+		{name: "below cap", policy: RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second}, attempt: 3, delay: 4 * time.Second},
+		{name: "initial is the cap", policy: RetryPolicy{InitialBackoff: time.Second, MaxBackoff: time.Second}, attempt: 10, delay: time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the jitter takes off up to half of the delay
+			for i := 0; i < 1000; i++ {
+				backoff := tt.policy.backoff(tt.attempt)
+				require.GreaterOrEqual(t, backoff, tt.delay/2)
+				require.LessOrEqual(t, backoff, tt.delay)
+			}
+		})
+	}
+}
+
+func Test_Service_Retry(t *testing.T) {
+	s := newTestService(t, ServiceConfig{})
+	w, err := s.Watch(context.Background(), 0, "")
+	require.NoError(t, err)
+	defer w.Close()
+
+	policy := RetryPolicy{MaxAttempts: 3, InitialBackoff: 10 * time.Millisecond}
+	record := startTestJob(t, s, StartOptions{Retry: policy}, "false")
+	for _, attempt := range []int{2, 3} {
+		event := nextEvent(t, w, EventRetrying)
+		require.Equal(t, record.ID, event.JobID)
+		require.Equal(t, attempt, event.Attempt)
+	}
+
+	ended, err := s.WaitJob(context.Background(), record.ID)
+	require.NoError(t, err)
+	require.Equal(t, 3, ended.Attempt)
+	require.Len(t, ended.Attempts, 2)
+	for i, attempt := range ended.Attempts {
+		require.Equal(t, i+1, attempt.Number)
+		require.Equal(t, jobs.StatusExited, attempt.Status)
+		require.Equal(t, 1, attempt.ExitCode)
+	}
+	state := ended.Job.State()
+	require.Equal(t, jobs.StatusExited, state.Status)
+	require.Equal(t, 1, state.ExitCode)
+}
+
+func Test_Service_RetryRemovedWhileWaiting(t *testing.T) {
+	s := newTestService(t, ServiceConfig{})
+	w, err := s.Watch(context.Background(), 0, "")
+	require.NoError(t, err)
+	defer w.Close()
+
+	policy := RetryPolicy{MaxAttempts: 2, InitialBackoff: 200 * time.Millisecond, MaxBackoff: 200 * time.Millisecond}
+	record := startTestJob(t, s, StartOptions{Retry: policy}, "false")
+	nextEvent(t, w, EventRetrying)
+
+	// the next attempt waits for its backoff to pass
+	waiting, err := s.GetJob(context.Background(), record.ID)
+	require.NoError(t, err)
+	require.Equal(t, 2, waiting.Attempt)
+	require.Equal(t, jobs.StatusQueued, waiting.Job.State().Status)
+	waited := make(chan error, 1)
+	go func() {
+		// the job is not found if it is removed first
+		_, err := s.WaitJob(context.Background(), record.ID)
+		waited <- err
+	}()
+	require.NoError(t, s.remove(waiting, false))
+
+	// once the backoff has passed nothing is started and the job stays removed
+	time.Sleep(400 * time.Millisecond)
+	_, err = s.GetJob(context.Background(), record.ID)
+	require.ErrorIs(t, err, ErrJobNotFound)
+	s.Lock()
+	require.Empty(t, s.retrying)
+	require.Zero(t, s.running)
+	s.Unlock()
+	// waiting on the job ends once the attempt is dropped
+	select {
+	case <-waited:
+	case <-time.After(5 * time.Second):
+		t.Fatal("WaitJob did not return once the job was removed")
+	}
+	for len(w.C) > 0 {
+		require.NotEqual(t, EventStarted, (<-w.C).Type)
+	}
+}
+
+func Test_Service_RestoreRetryWaiting(t *testing.T) {
+	store := NewMemoryStore()
+	s := newTestService(t, ServiceConfig{Store: store})
+	w, err := s.Watch(context.Background(), 0, "")
+	require.NoError(t, err)
+	defer w.Close()
+
+	policy := RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Minute}
+	record := startTestJob(t, s, StartOptions{Retry: policy}, "false")
+	event := nextEvent(t, w, EventRetrying)
+	stored, err := store.List()
+	require.NoError(t, err)
+	require.Equal(t, jobs.StatusQueued, stored[0].Status)
+	require.True(t, event.Time.Equal(stored[0].RetryAt))
+	s.Shutdown()
+
+	// the server restarts before the backoff has passed, the attempt waits for the rest of it
+	stored[0].RetryAt = time.Now().Add(300 * time.Millisecond)
+	require.NoError(t, store.Save(stored[0]))
+	restarted := newTestService(t, ServiceConfig{Store: store})
+	waiting, err := restarted.GetJob(context.Background(), record.ID)
+	require.NoError(t, err)
+	require.Equal(t, jobs.StatusQueued, waiting.Job.State().Status)
+	require.True(t, stored[0].RetryAt.Equal(waiting.RetryAt))
+	restarted.Lock()
+	require.Empty(t, restarted.queue)
+	require.Contains(t, restarted.retrying, record.ID)
+	restarted.Unlock()
+
+	ended, err := restarted.WaitJob(context.Background(), record.ID)
+	require.NoError(t, err)
+	require.Equal(t, 2, ended.Attempt)
+	require.Len(t, ended.Attempts, 1)
+	require.False(t, ended.Job.State().StartedAt.Before(stored[0].RetryAt))
+}
@@ -0,0 +1,326 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"syscall"
+	"time"
+
+	"job_runner/pkg/cron"
+	"job_runner/pkg/jobs"
+)
+
+// ErrScheduleNotFound is returned when no schedule has the requested id
+var ErrScheduleNotFound = errors.New("schedule not found")
+
+// ErrInvalidSchedule is returned when creating a schedule with an invalid cron expression or timezone
+var ErrInvalidSchedule = errors.New("invalid schedule")
+
+const (
+	// maxScheduleHistory is the number of job ids a schedule remembers
+	maxScheduleHistory = 100
+	// maxScheduleWait bounds how long the schedule loop sleeps, so it catches up with changes of the wall clock
+	maxScheduleWait = time.Minute
+)
+
+// ConcurrencyPolicy decides what a schedule does when the job it started last has not ended
+type ConcurrencyPolicy string
+
+const (
+	// ConcurrencyAllow starts another job alongside it
+	ConcurrencyAllow ConcurrencyPolicy = "allow"
+	// ConcurrencyForbid skips the run
+	ConcurrencyForbid ConcurrencyPolicy = "forbid"
+	// ConcurrencyReplace stops it and starts a new job once it has ended
+	ConcurrencyReplace ConcurrencyPolicy = "replace"
+)
+
+// Schedule starts a job on behalf of its owner every time its cron expression matches in its timezone.
+// Runs missed while the server was down or busy are skipped.
+type Schedule struct {
+	ID          int32             `json:"id"`
+	Owner       string            `json:"owner"`
+	Cron        string            `json:"cron"`
+	Timezone    string            `json:"timezone"`
+	Spec        jobs.Spec         `json:"spec"`
+	Options     StartOptions      `json:"options"`
+	Concurrency ConcurrencyPolicy `json:"concurrency"`
+	Paused      bool              `json:"paused,omitempty"`
+	// History holds the ids of the most recent jobs started by the schedule, oldest first
+	History   []int32   `json:"history,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	LastRun   time.Time `json:"last_run,omitempty"`
+
+	// NextRun is zero while the schedule is paused or when the expression never matches again
+	NextRun  time.Time `json:"-"`
+	cron     *cron.Schedule
+	location *time.Location
+}
+
+// parse sets the parsed cron expression and location of the schedule
+func (s *Schedule) parse() error {
+	var err error
+	if s.cron, err = cron.Parse(s.Cron); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSchedule, err)
+	}
+	if s.Timezone == "" {
+		s.Timezone = "UTC"
+	}
+	if s.location, err = time.LoadLocation(s.Timezone); err != nil {
+		return fmt.Errorf("%w: unknown timezone %q", ErrInvalidSchedule, s.Timezone)
+	}
+	return nil
+}
+
+// schedule sets the next run of the schedule after now
+func (s *Schedule) schedule(now time.Time) {
+	s.NextRun = time.Time{}
+	if !s.Paused {
+		s.NextRun = s.cron.Next(now.In(s.location))
+	}
+}
+
+// lastJob returns the id of the job started last, 0 if the schedule has not started any
+func (s Schedule) lastJob() int32 {
+	if len(s.History) == 0 {
+		return 0
+	}
+	return s.History[len(s.History)-1]
+}
+
+// copy returns the schedule with its own history, so it can be returned to callers outside the lock
+func (s Schedule) copy() Schedule {
+	s.History = append([]int32(nil), s.History...)
+	return s
+}
+
+// restoreSchedules loads the stored schedules, runs missed while the server was down are skipped
+func (s *Service) restoreSchedules() error {
+	stored, err := s.store.ListSchedules()
+	if err != nil {
+		return fmt.Errorf("store.ListSchedules: %w", err)
+	}
+	now := time.Now()
+	for _, schedule := range stored {
+		if schedule.ID > s.scheduleID {
+			s.scheduleID = schedule.ID
+		}
+		if err := schedule.parse(); err != nil {
+			fmt.Printf("error restoring schedule with id %d: %v\n", schedule.ID, err)
+			continue
+		}
+		schedule.schedule(now)
+		s.schedules[schedule.ID] = schedule
+	}
+	// schedules that were deleted may have had higher ids
+	maxID, err := s.store.MaxScheduleID()
+	if err != nil {
+		return fmt.Errorf("store.MaxScheduleID: %w", err)
+	}
+	if maxID > s.scheduleID {
+		s.scheduleID = maxID
+	}
+	return nil
+}
+
+// CreateSchedule validates and stores the schedule, the id and creation time are set by the service
+func (s *Service) CreateSchedule(ctx context.Context, schedule Schedule) (Schedule, error) {
+	if err := schedule.parse(); err != nil {
+		return Schedule{}, err
+	}
+	switch schedule.Concurrency {
+	case "":
+		schedule.Concurrency = ConcurrencyAllow
+	case ConcurrencyAllow, ConcurrencyForbid, ConcurrencyReplace:
+	default:
+		return Schedule{}, fmt.Errorf("%w: unknown concurrency policy %q", ErrInvalidSchedule, schedule.Concurrency)
+	}
+	schedule.History = nil
+	schedule.LastRun = time.Time{}
+	schedule.CreatedAt = time.Now()
+	schedule.schedule(schedule.CreatedAt)
+
+	s.schedMu.Lock()
+	s.scheduleID++
+	schedule.ID = s.scheduleID
+	if err := s.store.SaveSchedule(schedule); err != nil {
+		s.schedMu.Unlock()
+		return Schedule{}, fmt.Errorf("store.SaveSchedule: %w", err)
+	}
+	s.schedules[schedule.ID] = schedule
+	s.schedMu.Unlock()
+	s.wakeScheduler()
+	return schedule.copy(), nil
+}
+
+// ListSchedules returns the schedules ordered by id, only those of owner unless owner is empty
+func (s *Service) ListSchedules(ctx context.Context, owner string) []Schedule {
+	s.schedMu.Lock()
+	defer s.schedMu.Unlock()
+	schedules := make([]Schedule, 0, len(s.schedules))
+	for _, schedule := range s.schedules {
+		if owner == "" || schedule.Owner == owner {
+			schedules = append(schedules, schedule.copy())
+		}
+	}
+	sort.Slice(schedules, func(i, j int) bool { return schedules[i].ID < schedules[j].ID })
+	return schedules
+}
+
+func (s *Service) GetSchedule(ctx context.Context, id int32) (Schedule, error) {
+	s.schedMu.Lock()
+	defer s.schedMu.Unlock()
+	schedule, ok := s.schedules[id]
+	if !ok {
+		return Schedule{}, ErrScheduleNotFound
+	}
+	return schedule.copy(), nil
+}
+
+// DeleteSchedule removes the schedule, the jobs it started are kept
+func (s *Service) DeleteSchedule(ctx context.Context, id int32) error {
+	s.schedMu.Lock()
+	defer s.schedMu.Unlock()
+	if _, ok := s.schedules[id]; !ok {
+		return ErrScheduleNotFound
+	}
+	if err := s.store.DeleteSchedule(id); err != nil {
+		return fmt.Errorf("store.DeleteSchedule: %w", err)
+	}
+	delete(s.schedules, id)
+	return nil
+}
+
+// PauseSchedule pauses or resumes the schedule. A resumed schedule next runs when its expression matches after now,
+// runs missed while it was paused are skipped.
+func (s *Service) PauseSchedule(ctx context.Context, id int32, paused bool) (Schedule, error) {
+	s.schedMu.Lock()
+	schedule, ok := s.schedules[id]
+	if !ok {
+		s.schedMu.Unlock()
+		return Schedule{}, ErrScheduleNotFound
+	}
+	schedule.Paused = paused
+	schedule.schedule(time.Now())
+	if err := s.store.SaveSchedule(schedule); err != nil {
+		s.schedMu.Unlock()
+		return Schedule{}, fmt.Errorf("store.SaveSchedule: %w", err)
+	}
+	s.schedules[id] = schedule
+	s.schedMu.Unlock()
+	s.wakeScheduler()
+	return schedule.copy(), nil
+}
+
+// wakeScheduler makes the schedule loop recompute when the next schedule is due
+func (s *Service) wakeScheduler() {
+	select {
+	case s.scheduleWake <- struct{}{}:
+	default:
+	}
+}
+
+// scheduleLoop starts the jobs of schedules as they are due until the service shuts down
+func (s *Service) scheduleLoop() {
+	defer s.wg.Done()
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-s.parentCtx.Done():
+			return
+		case <-timer.C:
+		case <-s.scheduleWake:
+			if !timer.Stop() {
+				<-timer.C
+			}
+		}
+		wait := maxScheduleWait
+		if next := s.runDue(time.Now()); !next.IsZero() && time.Until(next) < wait {
+			wait = time.Until(next)
+		}
+		timer.Reset(wait)
+	}
+}
+
+// runDue starts the jobs of the schedules due at now and returns when the next schedule is due,
+// the zero time if none is
+func (s *Service) runDue(now time.Time) time.Time {
+	var due []Schedule
+	var next time.Time
+	s.schedMu.Lock()
+	for id, schedule := range s.schedules {
+		if !schedule.NextRun.IsZero() && !schedule.NextRun.After(now) {
+			due = append(due, schedule.copy())
+			schedule.schedule(now)
+			s.schedules[id] = schedule
+		}
+		if !schedule.NextRun.IsZero() && (next.IsZero() || schedule.NextRun.Before(next)) {
+			next = schedule.NextRun
+		}
+	}
+	s.schedMu.Unlock()
+
+	sort.Slice(due, func(i, j int) bool { return due[i].ID < due[j].ID })
+	for _, schedule := range due {
+		s.fire(schedule, now)
+	}
+	return next
+}
+
+// fire starts the job of the schedule, applying its concurrency policy to the job it started last
+func (s *Service) fire(schedule Schedule, now time.Time) {
+	if last, err := s.GetJob(s.parentCtx, schedule.lastJob()); err == nil && schedule.Concurrency != ConcurrencyAllow {
+		select {
+		case <-last.Job.Done():
+		default:
+			if schedule.Concurrency == ConcurrencyForbid {
+				fmt.Printf("schedule %d skipped a run, job %d has not ended\n", schedule.ID, last.ID)
+				return
+			}
+			// the new job starts once the replaced job has ended, without holding up other schedules
+			s.wg.Add(1)
+			go func() {
+				defer s.wg.Done()
+				if _, err := s.StopJob(s.parentCtx, last.ID, syscall.SIGTERM, defaultStopGrace); err != nil {
+					fmt.Printf("schedule %d error stopping job %d: %v\n", schedule.ID, last.ID, err)
+					return
+				}
+				s.startScheduled(schedule, now)
+			}()
+			return
+		}
+	}
+	s.startScheduled(schedule, now)
+}
+
+// startScheduled starts the job of the schedule and records it in the schedule's history
+func (s *Service) startScheduled(schedule Schedule, now time.Time) {
+	if s.parentCtx.Err() != nil {
+		return
+	}
+	record, err := s.StartJob(s.parentCtx, schedule.Owner, schedule.Spec, schedule.Options)
+	if err != nil {
+		fmt.Printf("schedule %d error starting job: %v\n", schedule.ID, err)
+		return
+	}
+
+	s.schedMu.Lock()
+	defer s.schedMu.Unlock()
+	current, ok := s.schedules[schedule.ID]
+	if !ok {
+		// deleted in the meantime
+		return
+	}
+	current.History = append(current.History, record.ID)
+	if len(current.History) > maxScheduleHistory {
+		current.History = current.History[len(current.History)-maxScheduleHistory:]
+	}
+	current.LastRun = now
+	if err := s.store.SaveSchedule(current); err != nil {
+		fmt.Printf("error saving schedule with id %d: %v\n", schedule.ID, err)
+	}
+	s.schedules[schedule.ID] = current
+}
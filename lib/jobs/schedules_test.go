@@ -0,0 +1,132 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"job_runner/pkg/jobs"
+)
+
+// yearly only matches at the start of the year, so the schedule loop does not run it while a test runs
+const yearly = "0 0 1 1 *"
+
+func createTestSchedule(t *testing.T, s *Service, concurrency ConcurrencyPolicy, command ...string) Schedule {
+	schedule, err := s.CreateSchedule(context.Background(), Schedule{
+		Owner:       "alice",
+		Cron:        yearly,
+		Spec:        jobs.Spec{Command: command, Limits: testLimits},
+		Concurrency: concurrency,
+	})
+	require.NoError(t, err)
+	return schedule
+}
+
+func Test_Service_RunDue(t *testing.T) {
+	s := newTestService(t, ServiceConfig{})
+	schedule := createTestSchedule(t, s, "", "true")
+	require.Equal(t, ConcurrencyAllow, schedule.Concurrency)
+	due := schedule.NextRun
+	require.Equal(t, time.January, due.Month())
+	require.Equal(t, 1, due.Day())
+
+	// nothing is due before the next run
+	require.Equal(t, due, s.runDue(due.Add(-time.Second)))
+	schedule, err := s.GetSchedule(context.Background(), schedule.ID)
+	require.NoError(t, err)
+	require.Empty(t, schedule.History)
+
+	next := s.runDue(due)
+	require.Equal(t, due.AddDate(1, 0, 0), next)
+	schedule, err = s.GetSchedule(context.Background(), schedule.ID)
+	require.NoError(t, err)
+	require.Len(t, schedule.History, 1)
+	require.Equal(t, due, schedule.LastRun)
+	require.Equal(t, next, schedule.NextRun)
+	_, err = s.WaitJob(context.Background(), schedule.History[0])
+	require.NoError(t, err)
+
+	// a paused schedule is never due
+	_, err = s.PauseSchedule(context.Background(), schedule.ID, true)
+	require.NoError(t, err)
+	require.True(t, s.runDue(next).IsZero())
+}
+
+func Test_Service_ScheduleLoop(t *testing.T) {
+	s := newTestService(t, ServiceConfig{})
+	schedule := createTestSchedule(t, s, "", "true")
+
+	// the loop runs the schedule once it is due, here as soon as it is woken up
+	s.schedMu.Lock()
+	current := s.schedules[schedule.ID]
+	current.NextRun = time.Now()
+	s.schedules[schedule.ID] = current
+	s.schedMu.Unlock()
+	s.wakeScheduler()
+
+	require.Eventually(t, func() bool {
+		schedule, err := s.GetSchedule(context.Background(), schedule.ID)
+		return err == nil && len(schedule.History) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	schedule, err := s.GetSchedule(context.Background(), schedule.ID)
+	require.NoError(t, err)
+	require.True(t, schedule.NextRun.After(time.Now()))
+}
+
+func Test_Service_ScheduleConcurrency(t *testing.T) {
+	tests := []struct {
+		policy ConcurrencyPolicy
+		// jobs started by the second run, and the status of the first job once the second run is done
+		started int
+		first   jobs.Status
+	}{
+		{policy: ConcurrencyAllow, started: 1, first: jobs.StatusRunning},
+		{policy: ConcurrencyForbid, started: 0, first: jobs.StatusRunning},
+		{policy: ConcurrencyReplace, started: 1, first: jobs.StatusStopped},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			s := newTestService(t, ServiceConfig{})
+			schedule := createTestSchedule(t, s, tt.policy, "sleep", "5")
+
+			next := s.runDue(schedule.NextRun)
+			schedule, err := s.GetSchedule(context.Background(), schedule.ID)
+			require.NoError(t, err)
+			require.Len(t, schedule.History, 1)
+			first := schedule.History[0]
+			waitStatus(t, s, first, jobs.StatusRunning)
+
+			s.runDue(next)
+			var history []int32
+			require.Eventually(t, func() bool {
+				schedule, err := s.GetSchedule(context.Background(), schedule.ID)
+				history = schedule.History
+				return err == nil && len(history) == 1+tt.started
+			}, 5*time.Second, 10*time.Millisecond)
+			waitStatus(t, s, first, tt.first)
+			if tt.started > 0 {
+				waitStatus(t, s, history[1], jobs.StatusRunning)
+			}
+		})
+	}
+}
+
+func Test_Service_ScheduleIDsNotReused(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewFileStore(dir)
+	require.NoError(t, err)
+	s, err := NewService(context.Background(), ServiceConfig{Store: store})
+	require.NoError(t, err)
+	createTestSchedule(t, s, "", "true")
+	newest := createTestSchedule(t, s, "", "true")
+	require.NoError(t, s.DeleteSchedule(context.Background(), newest.ID))
+	s.Shutdown()
+
+	store, err = NewFileStore(dir)
+	require.NoError(t, err)
+	s = newTestService(t, ServiceConfig{Store: store})
+	require.Len(t, s.ListSchedules(context.Background(), ""), 1)
+	require.Equal(t, newest.ID+1, createTestSchedule(t, s, "", "true").ID)
+}
@@ -2,112 +2,789 @@ package jobs
 
 import (
 	"context"
+	"encoding/base64"
+	"errors"
 	"fmt"
 	"io"
+	"sort"
+	"strconv"
 	"sync"
+	"syscall"
+	"time"
 
-	"job_runner/pkg/cgroupz"
 	"job_runner/pkg/jobs"
 )
 
+const (
+	defaultPageSize = 50
+	maxPageSize     = 500
+)
+
+// ErrJobNotFound is returned when no job has the requested id
+var ErrJobNotFound = errors.New("job not found")
+
+// ErrInvalidPageToken is returned by ListJobs when the page token was not issued by the service
+var ErrInvalidPageToken = errors.New("invalid page token")
+
 // A simple model for a Job executed by the service.
 type JobRecord struct {
-	ID     int32
-	Job    jobs.Job
+	ID      int32
+	Owner   string
+	Command []string
+	Job     *jobs.Job
+	// Summary is set once the output of the job has been removed
+	Summary bool
+	// QueuePosition is the position of a queued job in the queue starting at 1, 0 if the job is not queued
+	QueuePosition int
+	// Attempt is the number of the current attempt starting at 1, Attempts holds the earlier attempts
+	Attempt  int
+	Attempts []Attempt
+	// RetryAt is when the current attempt is admitted while it waits for its backoff to pass
+	RetryAt time.Time
+	StartOptions
 	cancel func()
-	ctx    context.Context
+	// closed once the job has ended and the service is done with it, when a preempted job has been queued again
+	// or once its last attempt has ended
+	settled chan struct{}
+}
+
+// ListFilter narrows down the jobs returned by ListJobs. Zero values match every job.
+type ListFilter struct {
+	Status        jobs.Status
+	Owner         string
+	StartedAfter  time.Time
+	StartedBefore time.Time
+
+	PageSize  int
+	PageToken string
+}
+
+func (f ListFilter) match(record JobRecord, state jobs.State) bool {
+	if f.Status != "" && f.Status != state.Status {
+		return false
+	}
+	if f.Owner != "" && f.Owner != record.Owner {
+		return false
+	}
+	if !f.StartedAfter.IsZero() && !state.StartedAt.After(f.StartedAfter) {
+		return false
+	}
+	if !f.StartedBefore.IsZero() && !state.StartedAt.Before(f.StartedBefore) {
+		return false
+	}
+	return true
+}
+
+// ServiceConfig configures a Service
+type ServiceConfig struct {
+	// Store persists jobs, defaults to a MemoryStore
+	Store Store
+	// OutputDir keeps the output of each job on disk in a directory named after its id, where it survives
+	// restarts. Output is kept in memory if empty.
+	OutputDir string
+	// Retention removes jobs that have ended, by default they are kept
+	Retention RetentionPolicy
+	// MaxRunning is the number of jobs that can run at once, further jobs are queued and started in order
+	// of priority as running jobs end. Zero runs every job right away.
+	MaxRunning int
+	// PreemptGrace is the time a preempted job has to exit after SIGTERM before it is killed, defaults to 10s
+	PreemptGrace time.Duration
+	// OutputBudget is the number of bytes of output all jobs together may keep, counting the output limit of jobs
+	// that have not ended for each attempt they may still run. Jobs that do not fit are not started, and every job
+	// needs an output limit. Zero does not cap the output of all jobs.
+	OutputBudget int64
 }
 
 // Service handles the basic API of dealing with multiple Jobs
 type Service struct {
 	ider
 	sync.Mutex
-	store map[int32]JobRecord
+	records   map[int32]JobRecord
+	store     Store
+	outputDir string
+	retention RetentionPolicy
+	// bytes of output all jobs together may keep, guarded by the mutex along with the records it is checked against
+	outputBudget int64
+
+	// admission control, guarded by the mutex
+	maxRunning int
+	running    int
+	// ids of the queued jobs by descending priority, in the order they were queued within a priority
+	queue []int32
+	// running jobs that are being preempted, mapped to whether they are queued again once they end
+	preempted    map[int32]bool
+	preempting   int
+	preemptGrace time.Duration
+	// attempts waiting for their backoff to pass, and running jobs stopped through StopJob which are not retried
+	retrying map[int32]*time.Timer
+	stopping map[int32]bool
+	// statuses each job has had, guarded by the mutex
+	transitions map[int32][]StatusTransition
+
+	// schedules are guarded by their own mutex, as starting their jobs takes the service's
+	schedMu      sync.Mutex
+	schedules    map[int32]Schedule
+	scheduleID   int32
+	scheduleWake chan struct{}
+
+	// workflows are guarded by their own mutex as well
+	wfMu       sync.Mutex
+	workflows  map[int32]Workflow
+	workflowID int32
+
+	events *eventBus
 
 	wg        sync.WaitGroup
 	parentCtx context.Context
 	cancel    func()
 }
 
-func NewService(ctx context.Context) *Service {
+// NewService returns a Service holding the jobs found in the configured store. Jobs that were running when
+// the store was last written are marked as lost, and new jobs are numbered after the highest id stored.
+func NewService(ctx context.Context, config ServiceConfig) (*Service, error) {
+	store := config.Store
+	if store == nil {
+		store = NewMemoryStore()
+	}
 	parentCtx, cancel := context.WithCancel(ctx)
-	return &Service{
+	s := &Service{
 		parentCtx: parentCtx,
 		cancel:    cancel,
-		store:     make(map[int32]JobRecord),
+		records:   make(map[int32]JobRecord),
+		store:     store,
+		outputDir: config.OutputDir,
+		retention: config.Retention,
+		events:    newEventBus(),
+
+		outputBudget: config.OutputBudget,
+
+		maxRunning:   config.MaxRunning,
+		preempted:    make(map[int32]bool),
+		preemptGrace: config.PreemptGrace,
+		retrying:     make(map[int32]*time.Timer),
+		stopping:     make(map[int32]bool),
+		transitions:  make(map[int32][]StatusTransition),
+
+		schedules:    make(map[int32]Schedule),
+		scheduleWake: make(chan struct{}, 1),
+
+		workflows: make(map[int32]Workflow),
+	}
+	if s.preemptGrace <= 0 {
+		s.preemptGrace = defaultPreemptGrace
+	}
+	if err := s.restore(); err != nil {
+		cancel()
+		return nil, err
+	}
+	if err := s.restoreSchedules(); err != nil {
+		cancel()
+		return nil, err
 	}
+	if s.retention.enabled() {
+		s.wg.Add(1)
+		go s.collectLoop()
+	}
+	s.dispatch()
+	// workflows are resumed once queued jobs were dispatched, as they start further jobs
+	if err := s.restoreWorkflows(); err != nil {
+		s.Shutdown()
+		return nil, err
+	}
+	s.wg.Add(1)
+	go s.scheduleLoop()
+	return s, nil
 }
 
-func (s *Service) StartJob(ctx context.Context, cmdStr []string, limits cgroupz.ResourceLimit) (JobRecord, error) {
-	jobCtx, cancel := context.WithCancel(ctx)
-	job := jobs.New(jobCtx, cmdStr, limits)
-	id := s.nextID()
+// restore loads the stored jobs into the service, queued jobs are queued again in order and attempts waiting for
+// their backoff to pass wait for the rest of it
+func (s *Service) restore() error {
+	stored, err := s.store.List()
+	if err != nil {
+		return fmt.Errorf("store.List: %w", err)
+	}
+	var waiting []JobRecord
+	for _, job := range stored {
+		s.transitions[job.ID] = job.Transitions
+		if job.Status == jobs.StatusQueued {
+			record := s.newRecord(job.ID, job.Owner, job.Spec, job.Options, job.Attempt)
+			record.Attempts = restoreAttempts(job)
+			record.RetryAt = job.RetryAt
+			record.Job.Queue()
+			s.records[job.ID] = record
+			if record.RetryAt.IsZero() {
+				s.enqueue(job.ID)
+			} else {
+				waiting = append(waiting, record)
+			}
+			if job.ID > s.id {
+				s.id = job.ID
+			}
+			continue
+		}
+		if job.EndedAt.IsZero() {
+			// the job was running or about to run, its process did not survive the restart
+			job.Status = jobs.StatusLost
+			job.ExitCode = -1
+			job.EndedAt = time.Now()
+			job.Transitions = s.transition(job)
+			if err := s.store.Save(job); err != nil {
+				return fmt.Errorf("store.Save: %w", err)
+			}
+		}
+		if job.Attempt < 1 {
+			// jobs stored before retries were added
+			job.Attempt = 1
+		}
+		s.records[job.ID] = JobRecord{
+			ID:      job.ID,
+			Owner:   job.Owner,
+			Command: job.Spec.Command,
+			Job:     jobs.Restore(job.Spec, job.state()),
+			Summary: job.Summary,
+			cancel:  func() {},
+			settled: settledChan(),
+
+			Attempt:  job.Attempt,
+			Attempts: restoreAttempts(job),
+
+			StartOptions: job.Options,
+		}
+		if job.ID > s.id {
+			s.id = job.ID
+		}
+	}
+	// jobs that were deleted may have had higher ids
+	maxID, err := s.store.MaxID()
+	if err != nil {
+		return fmt.Errorf("store.MaxID: %w", err)
+	}
+	if maxID > s.id {
+		s.id = maxID
+	}
+	// the timers are armed once every job is restored, as they may fire right away
+	s.Lock()
+	for _, record := range waiting {
+		s.armRetry(record)
+	}
+	s.Unlock()
+	return nil
+}
+
+// save persists the current state of the job, failures are logged as the job itself is unaffected
+func (s *Service) save(record JobRecord) {
+	if err := s.store.Save(s.storedJob(record)); err != nil {
+		fmt.Printf("error saving job with id %d: %v\n", record.ID, err)
+	}
+}
+
+// storedJob returns the persisted form of record along with the statuses the job has had
+func (s *Service) storedJob(record JobRecord) StoredJob {
+	stored := toStoredJob(record)
+	stored.Transitions = s.transition(stored)
+	return stored
+}
 
-	record := JobRecord{ID: id, Job: job, cancel: cancel, ctx: jobCtx}
+// transition records the status of stored if it differs from the last one the job had and returns every status
+// the job has had. A job that has not been queued or started yet has no status.
+func (s *Service) transition(stored StoredJob) []StatusTransition {
+	s.Lock()
+	defer s.Unlock()
+	transitions := s.transitions[stored.ID]
+	n := len(transitions)
+	if (n == 0 && stored.Status == jobs.StatusUnknown) || (n > 0 && transitions[n-1].Status == stored.Status) {
+		return append([]StatusTransition(nil), transitions...)
+	}
+	// the job started or ended before it was saved, when is known more precisely than now
+	at := time.Now()
+	if !stored.EndedAt.IsZero() {
+		at = stored.EndedAt
+	} else if stored.Status == jobs.StatusRunning {
+		at = stored.StartedAt
+	}
+	transitions = append(transitions, StatusTransition{Status: stored.Status, Time: at})
+	s.transitions[stored.ID] = transitions
+	return append([]StatusTransition(nil), transitions...)
+}
+
+// newRecord returns the record of an attempt of a job that has not started
+func (s *Service) newRecord(id int32, owner string, spec jobs.Spec, opts StartOptions, attempt int) JobRecord {
+	if attempt < 1 {
+		// jobs stored before retries were added
+		attempt = 1
+	}
+	if s.outputDir != "" {
+		spec.OutputDir = s.attemptOutputDir(id, attempt)
+	}
+	jobCtx, cancel := context.WithCancel(s.parentCtx)
+	return JobRecord{
+		ID:           id,
+		Owner:        owner,
+		Command:      spec.Command,
+		Job:          jobs.New(jobCtx, spec),
+		StartOptions: opts,
+		Attempt:      attempt,
+		cancel:       cancel,
+		settled:      make(chan struct{}),
+	}
+}
+
+// settledChan returns a closed channel for the records of jobs that ended before the service started
+func settledChan() chan struct{} {
+	settled := make(chan struct{})
+	close(settled)
+	return settled
+}
+
+// StartJob starts the job described by spec on behalf of owner, or queues it if MaxRunning jobs are running.
+// A job whose output does not fit in the OutputBudget is not started and an error wrapping ErrOutputBudget is returned.
+// The job outlives the passed context, it is only cancelled by StopJob or Shutdown.
+func (s *Service) StartJob(ctx context.Context, owner string, spec jobs.Spec, opts StartOptions) (JobRecord, error) {
+	id := s.nextID()
+	record := s.newRecord(id, owner, spec, opts, 1)
 
 	s.Lock()
-	if _, ok := s.store[id]; ok {
+	if _, ok := s.records[id]; ok {
+		s.Unlock()
+		record.cancel()
 		return JobRecord{}, fmt.Errorf("id %d already exists", id)
 	}
-	s.store[id] = record
+	if err := s.checkOutputBudget(record); err != nil {
+		s.Unlock()
+		record.cancel()
+		return JobRecord{}, err
+	}
+	s.records[id] = record
 	s.Unlock()
+	if err := s.store.Save(s.storedJob(record)); err != nil {
+		s.Lock()
+		delete(s.records, id)
+		s.Unlock()
+		record.cancel()
+		return JobRecord{}, fmt.Errorf("store.Save: %w", err)
+	}
+	s.events.publish(Event{Type: EventCreated, JobID: id, Owner: owner})
+	return s.admit(record)
+}
 
-	if err := job.Start(); err != nil {
+// checkOutputBudget returns an error wrapping ErrOutputBudget if the output the job of record may keep does not fit
+// in the output budget along with the output of every other job. The caller must hold the lock.
+func (s *Service) checkOutputBudget(record JobRecord) error {
+	if s.outputBudget == 0 {
+		return nil
+	}
+	if record.Job.Spec().OutputLimit == 0 {
+		return fmt.Errorf("%w: jobs need an output limit", ErrOutputBudget)
+	}
+	var used int64
+	for _, other := range s.records {
+		used += outputReserved(other)
+	}
+	if needed := outputReserved(record); used+needed > s.outputBudget {
+		left := s.outputBudget - used
+		if left < 0 {
+			left = 0
+		}
+		return fmt.Errorf("%w: the job may keep %d bytes of output, %d of %d bytes are left", ErrOutputBudget, needed, left, s.outputBudget)
+	}
+	return nil
+}
+
+// admit runs the job of record if there is a free running slot and no job is queued, otherwise it queues it
+func (s *Service) admit(record JobRecord) (JobRecord, error) {
+	s.Lock()
+	if s.maxRunning > 0 && (s.running >= s.maxRunning || len(s.queue) > 0) {
+		record.Job.Queue()
+		record.QueuePosition = s.enqueue(record.ID)
+		s.Unlock()
+		s.save(record)
+		s.events.publish(Event{Type: EventQueued, JobID: record.ID, Owner: record.Owner})
+		if record.Preempt {
+			s.preempt(record.Priority, record.QueuePosition)
+		}
+		return record, nil
+	}
+	s.running++
+	s.Unlock()
+
+	if err := s.run(record); err != nil {
 		return JobRecord{}, err
 	}
+	return record, nil
+}
+
+// run starts the job of record, which holds one of the running slots until it ends
+func (s *Service) run(record JobRecord) error {
+	id, owner, job := record.ID, record.Owner, record.Job
+	if err := job.Start(); err != nil {
+		record.cancel()
+		s.save(record)
+		s.events.publish(Event{Type: EventFailed, JobID: id, Owner: owner, Err: err})
+		s.release(record)
+		return err
+	}
+	s.save(record)
+	s.events.publish(Event{Type: EventStarted, JobID: id, Owner: owner, Time: job.State().StartedAt})
+
+	var deadline *time.Timer
+	if record.Timeout > 0 {
+		deadline = time.AfterFunc(record.Timeout, func() { s.timeout(record) })
+	}
 
 	s.wg.Add(1)
 	go func() {
 		defer s.wg.Done()
+		// releases the job context once the job has ended
+		defer record.cancel()
 		err := job.Wait()
+		if deadline != nil {
+			deadline.Stop()
+		}
 		if err != nil {
 			fmt.Printf("error executing job with id %d: %v\n", id, err)
 		}
+		s.save(record)
+		s.events.publish(endEvent(record, err))
+		s.release(record)
 	}()
+	return nil
+}
 
-	return record, nil
+// timeout stops the job of record, which has run past its timeout, through the same path as StopJob
+func (s *Service) timeout(record JobRecord) {
+	select {
+	case <-record.Job.Done():
+		return
+	default:
+	}
+	s.events.publish(Event{Type: EventTimedOut, JobID: record.ID, Owner: record.Owner})
+	if err := record.Job.Timeout(syscall.SIGTERM, defaultStopGrace); err != nil {
+		fmt.Printf("error stopping job with id %d that timed out: %v\n", record.ID, err)
+	}
+}
+
+// release frees the running slot of the job of record once it has ended, queues it again if it was preempted
+// and asked to be requeued or retries it if its retry policy says so, and starts the next queued jobs
+func (s *Service) release(record JobRecord) {
+	s.Lock()
+	s.running--
+	requeue, preempted := s.preempted[record.ID]
+	if preempted {
+		delete(s.preempted, record.ID)
+		s.preempting--
+	}
+	stopped := s.stopping[record.ID]
+	delete(s.stopping, record.ID)
+	s.Unlock()
+	switch {
+	case requeue && record.RequeueOnPreempt && s.parentCtx.Err() == nil:
+		if err := s.requeue(record); err != nil {
+			fmt.Printf("error queueing preempted job with id %d: %v\n", record.ID, err)
+		}
+	case s.retry(record, stopped):
+		// the job has not settled until its last attempt has ended
+		s.dispatch()
+		return
+	}
+	close(record.settled)
+	s.dispatch()
+}
+
+// dispatch starts queued jobs in order while there are free running slots. Nothing is started once the
+// service is shutting down, queued jobs are kept in the store and queued again on the next start.
+func (s *Service) dispatch() {
+	for {
+		s.Lock()
+		if s.parentCtx.Err() != nil || len(s.queue) == 0 || (s.maxRunning > 0 && s.running >= s.maxRunning) {
+			s.Unlock()
+			return
+		}
+		id := s.queue[0]
+		s.queue = s.queue[1:]
+		record := s.records[id]
+		s.running++
+		s.Unlock()
+
+		if err := s.run(record); err != nil {
+			fmt.Printf("error starting queued job with id %d: %v\n", id, err)
+		}
+	}
+}
+
+// cancelQueued removes a queued job from the queue, or an attempt waiting for its backoff, and cancels it.
+// It reports false if the job is not queued.
+func (s *Service) cancelQueued(record JobRecord) (bool, error) {
+	s.Lock()
+	timer, retrying := s.retrying[record.ID]
+	if retrying {
+		timer.Stop()
+		delete(s.retrying, record.ID)
+	}
+	if !retrying && !s.dequeue(record.ID) {
+		s.Unlock()
+		return false, nil
+	}
+	// the record may have been updated since the caller got it
+	record = s.records[record.ID]
+	record.RetryAt = time.Time{}
+	s.records[record.ID] = record
+	s.Unlock()
+
+	if err := record.Job.Cancel(); err != nil {
+		return true, fmt.Errorf("job.Cancel: %w", err)
+	}
+	record.cancel()
+	close(record.settled)
+	s.save(record)
+	s.events.publish(Event{Type: EventCancelled, JobID: record.ID, Owner: record.Owner, Time: record.Job.State().EndedAt})
+	return true, nil
+}
+
+// queuePositions returns the position in the queue of each queued job, the caller must hold the lock
+func (s *Service) queuePositions() map[int32]int {
+	positions := make(map[int32]int, len(s.queue))
+	for i, id := range s.queue {
+		positions[id] = i + 1
+	}
+	return positions
+}
+
+// endEvent describes how the job of record ended, err is the error returned by Wait
+func endEvent(record JobRecord, err error) Event {
+	state := record.Job.State()
+	event := Event{JobID: record.ID, Owner: record.Owner, Time: state.EndedAt}
+	switch {
+	case err != nil && state.Status == jobs.StatusUnknown:
+		event.Type = EventFailed
+		event.Err = err
+	case state.Status == jobs.StatusStopped || state.Status == jobs.StatusOutputLimit || state.Status == jobs.StatusPreempted ||
+		state.Status == jobs.StatusTimedOut:
+		event.Type = EventStopped
+		event.Signal = state.Signal
+	case state.Status == jobs.StatusOOMKilled:
+		event.Type = EventOOMKilled
+		event.Signal = state.Signal
+	default:
+		event.Type = EventExited
+		event.ExitCode = state.ExitCode
+	}
+	return event
+}
+
+// Watch subscribes to the lifecycle events of the job with jobID, or of every job when jobID is 0.
+// A non-empty owner only matches the events of jobs started by owner. The returned Watcher must be closed once done.
+func (s *Service) Watch(ctx context.Context, jobID int32, owner string) (*Watcher, error) {
+	filter := func(event Event) bool {
+		return (jobID == 0 || event.JobID == jobID) && (owner == "" || event.Owner == owner)
+	}
+	w := s.events.subscribe(filter)
+	if jobID != 0 {
+		// subscribe first so no event is missed between the check and the subscription
+		if _, err := s.GetJob(ctx, jobID); err != nil {
+			w.Close()
+			return nil, err
+		}
+	}
+	return w, nil
 }
 
 func (s *Service) GetJob(ctx context.Context, jobID int32) (JobRecord, error) {
 	s.Lock()
 	defer s.Unlock()
-	job, ok := s.store[jobID]
+	job, ok := s.records[jobID]
 	if !ok {
-		return JobRecord{}, fmt.Errorf("job not found")
+		return JobRecord{}, ErrJobNotFound
 	}
+	job.QueuePosition = s.queuePositions()[jobID]
 	return job, nil
 }
 
-func (s *Service) StopJob(ctx context.Context, jobID int32) (int, jobs.Status, error) {
+// ListJobs returns the jobs matching filter ordered by id, along with the token for the next page.
+// The returned token is empty when there are no more results.
+func (s *Service) ListJobs(ctx context.Context, filter ListFilter) ([]JobRecord, string, error) {
+	after, err := decodePageToken(filter.PageToken)
+	if err != nil {
+		return nil, "", err
+	}
+	pageSize := filter.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
 	s.Lock()
-	job, ok := s.store[jobID]
-	if !ok {
-		s.Unlock()
-		return -1, "", fmt.Errorf("job not found")
+	positions := s.queuePositions()
+	records := make([]JobRecord, 0, len(s.records))
+	for id, record := range s.records {
+		if id > after {
+			record.QueuePosition = positions[id]
+			records = append(records, record)
+		}
+	}
+	s.Unlock()
+	sort.Slice(records, func(i, j int) bool { return records[i].ID < records[j].ID })
+
+	var page []JobRecord
+	for _, record := range records {
+		if !filter.match(record, record.Job.State()) {
+			continue
+		}
+		if len(page) == pageSize {
+			return page, encodePageToken(page[len(page)-1].ID), nil
+		}
+		page = append(page, record)
+	}
+	return page, "", nil
+}
+
+// StopJob sends sig to the job and kills it once grace has passed. It returns when the job has ended,
+// if ctx is done first the job is still stopped. A queued job is cancelled instead.
+func (s *Service) StopJob(ctx context.Context, jobID int32, sig syscall.Signal, grace time.Duration) (JobRecord, error) {
+	job, err := s.GetJob(ctx, jobID)
+	if err != nil {
+		return JobRecord{}, err
+	}
+	if cancelled, err := s.cancelQueued(job); cancelled || err != nil {
+		job.QueuePosition = 0
+		return job, err
+	}
+	s.Lock()
+	if _, ok := s.preempted[jobID]; ok {
+		// a job that is stopped is not queued again after it was preempted
+		s.preempted[jobID] = false
+	}
+	// nor retried. Only a job that has not ended is marked, as release clears the mark once the job ends.
+	select {
+	case <-job.Job.Done():
+	default:
+		s.stopping[jobID] = true
 	}
-	job.cancel()
 	s.Unlock()
+	go func() {
+		if err := job.Job.Stop(sig, grace); err != nil {
+			fmt.Printf("error stopping job with id %d: %v\n", jobID, err)
+		}
+	}()
 	select {
-	case <-job.ctx.Done():
-		code, status := job.Job.Result()
-		return code, status, nil
+	case <-job.Job.Done():
+		return job, nil
 	case <-ctx.Done():
-		return -1, "", ctx.Err()
+		return JobRecord{}, ctx.Err()
 	}
 }
 
-func (s *Service) StreamJob(ctx context.Context, jobID int32, writer io.Writer) error {
+// SignalJob sends sig to the command of the job, or to every process of the job if all is set
+func (s *Service) SignalJob(ctx context.Context, jobID int32, sig syscall.Signal, all bool) error {
 	job, err := s.GetJob(ctx, jobID)
 	if err != nil {
 		return fmt.Errorf("getJob: %w", err)
 	}
-	if err := job.Job.Stream(ctx, writer); err != nil {
+	if all {
+		err = job.Job.SignalAll(sig)
+	} else {
+		err = job.Job.Signal(sig)
+	}
+	if err != nil {
+		return fmt.Errorf("job.Signal: %w", err)
+	}
+	return nil
+}
+
+// WaitJob blocks until the job has ended or ctx is done. A job that is queued again after it was preempted
+// has not ended.
+func (s *Service) WaitJob(ctx context.Context, jobID int32) (JobRecord, error) {
+	for {
+		job, err := s.GetJob(ctx, jobID)
+		if err != nil {
+			return JobRecord{}, fmt.Errorf("getJob: %w", err)
+		}
+		select {
+		case <-job.settled:
+		case <-ctx.Done():
+			return JobRecord{}, ctx.Err()
+		}
+		current, err := s.GetJob(ctx, jobID)
+		if err != nil || current.Job == job.Job {
+			return job, nil
+		}
+	}
+}
+
+// StreamJob streams the output of an attempt of the job to writer, attempt 0 streams the current attempt
+func (s *Service) StreamJob(ctx context.Context, jobID int32, attempt int, opts jobs.StreamOptions, writer io.WriterAt) error {
+	record, err := s.GetJob(ctx, jobID)
+	if err != nil {
+		return fmt.Errorf("getJob: %w", err)
+	}
+	job, err := record.attemptJob(attempt)
+	if err != nil {
+		return err
+	}
+	if err := job.StreamAt(ctx, writer, opts); err != nil {
 		return fmt.Errorf("job.Stream: %w", err)
 	}
 	return nil
 }
 
+// WriteStdin writes p to the stdin of the job
+func (s *Service) WriteStdin(ctx context.Context, jobID int32, p []byte) error {
+	job, err := s.GetJob(ctx, jobID)
+	if err != nil {
+		return fmt.Errorf("getJob: %w", err)
+	}
+	if _, err := job.Job.WriteStdin(p); err != nil {
+		return fmt.Errorf("job.WriteStdin: %w", err)
+	}
+	return nil
+}
+
+// CloseStdin closes the stdin of the job so the command reads EOF
+func (s *Service) CloseStdin(ctx context.Context, jobID int32) error {
+	job, err := s.GetJob(ctx, jobID)
+	if err != nil {
+		return fmt.Errorf("getJob: %w", err)
+	}
+	if err := job.Job.CloseStdin(); err != nil {
+		return fmt.Errorf("job.CloseStdin: %w", err)
+	}
+	return nil
+}
+
+// ResizeTerminal sets the window size of the terminal of a job running with a tty
+func (s *Service) ResizeTerminal(ctx context.Context, jobID int32, rows, cols uint16) error {
+	job, err := s.GetJob(ctx, jobID)
+	if err != nil {
+		return fmt.Errorf("getJob: %w", err)
+	}
+	if err := job.Job.Resize(rows, cols); err != nil {
+		return fmt.Errorf("job.Resize: %w", err)
+	}
+	return nil
+}
+
 func (s *Service) Shutdown() {
 	s.cancel()
 	s.wg.Wait()
 }
+
+// page tokens are opaque to callers, they encode the last id of the previous page
+func encodePageToken(id int32) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(int(id))))
+}
+
+func decodePageToken(token string) (int32, error) {
+	if token == "" {
+		return 0, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, ErrInvalidPageToken
+	}
+	id, err := strconv.ParseInt(string(raw), 10, 32)
+	if err != nil {
+		return 0, ErrInvalidPageToken
+	}
+	return int32(id), nil
+}
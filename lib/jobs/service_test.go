@@ -0,0 +1,341 @@
+package jobs
+
+import (
+	"context"
+	"encoding/base64"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"job_runner/pkg/cgroupz"
+	"job_runner/pkg/jobs"
+)
+
+// these tests run real jobs and must be run in a linux vm
+
+var testLimits = cgroupz.ResourceLimit{CpuWeight: 50, MaxMem: 1e8}
+
+func newTestService(t *testing.T, config ServiceConfig) *Service {
+	s, err := NewService(context.Background(), config)
+	require.NoError(t, err)
+	t.Cleanup(s.Shutdown)
+	return s
+}
+
+func startTestJob(t *testing.T, s *Service, opts StartOptions, command ...string) JobRecord {
+	record, err := s.StartJob(context.Background(), "alice", jobs.Spec{Command: command, Limits: testLimits}, opts)
+	require.NoError(t, err)
+	return record
+}
+
+func jobStatus(t *testing.T, s *Service, id int32) jobs.Status {
+	record, err := s.GetJob(context.Background(), id)
+	require.NoError(t, err)
+	return record.Job.State().Status
+}
+
+func waitStatus(t *testing.T, s *Service, id int32, want jobs.Status) {
+	require.Eventually(t, func() bool {
+		record, err := s.GetJob(context.Background(), id)
+		return err == nil && record.Job.State().Status == want
+	}, 5*time.Second, 10*time.Millisecond, "job %d did not become %s", id, want)
+}
+
+func stopTestJob(t *testing.T, s *Service, id int32) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err := s.StopJob(ctx, id, syscall.SIGKILL, time.Second)
+	require.NoError(t, err)
+}
+
+// nextEvent returns the next event of w of one of types, skipping the others
+func nextEvent(t *testing.T, w *Watcher, types ...EventType) Event {
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case event, ok := <-w.C:
+			require.True(t, ok, "watcher closed")
+			for _, typ := range types {
+				if event.Type == typ {
+					return event
+				}
+			}
+		case <-timeout:
+			t.Fatalf("no %v event", types)
+			return Event{}
+		}
+	}
+}
+
+func Test_Service_Enqueue(t *testing.T) {
+	tests := []struct {
+		name     string
+		queued   []int32
+		priority int32
+		queue    []int32
+		position int
+	}{
+		{name: "empty queue", queue: []int32{10}, position: 1},
+		{name: "same priority goes last", queued: []int32{0, 0}, queue: []int32{1, 2, 10}, position: 3},
+		{name: "higher priority goes first", queued: []int32{0, 0}, priority: 5, queue: []int32{10, 1, 2}, position: 1},
+		{name: "after higher and equal priorities", queued: []int32{9, 5, 5, 1}, priority: 5, queue: []int32{1, 2, 3, 10, 4}, position: 4},
+		{name: "lower priority goes last", queued: []int32{3, 2}, priority: -1, queue: []int32{1, 2, 10}, position: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Service{records: make(map[int32]JobRecord)}
+			for i, priority := range tt.queued {
+				id := int32(i + 1)
+				s.records[id] = JobRecord{ID: id, StartOptions: StartOptions{Priority: priority}}
+				s.queue = append(s.queue, id)
+			}
+			s.records[10] = JobRecord{ID: 10, StartOptions: StartOptions{Priority: tt.priority}}
+
+			require.Equal(t, tt.position, s.enqueue(10))
+			require.Equal(t, tt.queue, s.queue)
+		})
+	}
+}
+
+func Test_Service_Dequeue(t *testing.T) {
+	tests := []struct {
+		name  string
+		id    int32
+		ok    bool
+		queue []int32
+	}{
+		{name: "first", id: 1, ok: true, queue: []int32{2, 3}},
+		{name: "middle", id: 2, ok: true, queue: []int32{1, 3}},
+		{name: "last", id: 3, ok: true, queue: []int32{1, 2}},
+		{name: "not queued", id: 4, queue: []int32{1, 2, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Service{queue: []int32{1, 2, 3}}
+			require.Equal(t, tt.ok, s.dequeue(tt.id))
+			require.Equal(t, tt.queue, s.queue)
+		})
+	}
+}
+
+func Test_Service_QueueAdmissionAndDispatchOrder(t *testing.T) {
+	s := newTestService(t, ServiceConfig{MaxRunning: 1})
+	w, err := s.Watch(context.Background(), 0, "")
+	require.NoError(t, err)
+	defer w.Close()
+
+	running := startTestJob(t, s, StartOptions{}, "sleep", "5")
+	require.Zero(t, running.QueuePosition)
+	waitStatus(t, s, running.ID, jobs.StatusRunning)
+
+	low := startTestJob(t, s, StartOptions{}, "true")
+	high := startTestJob(t, s, StartOptions{Priority: 5}, "true")
+	high2 := startTestJob(t, s, StartOptions{Priority: 5}, "true")
+	require.Equal(t, 1, low.QueuePosition)
+	require.Equal(t, 1, high.QueuePosition)
+	require.Equal(t, 2, high2.QueuePosition)
+
+	for id, position := range map[int32]int{high.ID: 1, high2.ID: 2, low.ID: 3} {
+		record, err := s.GetJob(context.Background(), id)
+		require.NoError(t, err)
+		require.Equal(t, position, record.QueuePosition)
+		require.Equal(t, jobs.StatusQueued, record.Job.State().Status)
+	}
+
+	require.Equal(t, running.ID, nextEvent(t, w, EventStarted).JobID)
+	stopTestJob(t, s, running.ID)
+	// one job runs at a time, so the queued jobs start in order of priority and then of arrival
+	for _, id := range []int32{high.ID, high2.ID, low.ID} {
+		require.Equal(t, id, nextEvent(t, w, EventStarted).JobID)
+		require.Equal(t, id, nextEvent(t, w, EventExited).JobID)
+	}
+}
+
+func Test_Service_CancelQueued(t *testing.T) {
+	s := newTestService(t, ServiceConfig{MaxRunning: 1})
+	running := startTestJob(t, s, StartOptions{}, "sleep", "5")
+	queued := startTestJob(t, s, StartOptions{}, "true")
+	w, err := s.Watch(context.Background(), queued.ID, "")
+	require.NoError(t, err)
+	defer w.Close()
+
+	// the running job is not queued
+	cancelled, err := s.cancelQueued(running)
+	require.NoError(t, err)
+	require.False(t, cancelled)
+
+	record, err := s.StopJob(context.Background(), queued.ID, syscall.SIGTERM, time.Second)
+	require.NoError(t, err)
+	require.Zero(t, record.QueuePosition)
+	require.Equal(t, jobs.StatusCancelled, record.Job.State().Status)
+	require.Equal(t, EventCancelled, nextEvent(t, w, EventCancelled, EventStarted).Type)
+	s.Lock()
+	require.Empty(t, s.queue)
+	require.Empty(t, s.stopping)
+	s.Unlock()
+
+	// a cancelled job has settled and is not started once a running slot frees up
+	_, err = s.WaitJob(context.Background(), queued.ID)
+	require.NoError(t, err)
+	stopTestJob(t, s, running.ID)
+	require.Equal(t, jobs.StatusCancelled, jobStatus(t, s, queued.ID))
+
+	cancelled, err = s.cancelQueued(queued)
+	require.NoError(t, err)
+	require.False(t, cancelled)
+}
+
+func Test_Service_WaitJobQueued(t *testing.T) {
+	s := newTestService(t, ServiceConfig{MaxRunning: 1})
+	running := startTestJob(t, s, StartOptions{}, "sleep", "5")
+	queued := startTestJob(t, s, StartOptions{}, "true")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err := s.WaitJob(ctx, queued.ID)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	waited := make(chan JobRecord, 1)
+	go func() {
+		record, _ := s.WaitJob(context.Background(), queued.ID)
+		waited <- record
+	}()
+	stopTestJob(t, s, running.ID)
+
+	select {
+	case record := <-waited:
+		require.NotNil(t, record.Job)
+		state := record.Job.State()
+		require.Equal(t, jobs.StatusExited, state.Status)
+		require.Equal(t, 0, state.ExitCode)
+	case <-time.After(5 * time.Second):
+		t.Fatal("WaitJob did not return once the queued job ended")
+	}
+}
+
+func Test_Service_StopEndedJob(t *testing.T) {
+	s := newTestService(t, ServiceConfig{})
+	record := startTestJob(t, s, StartOptions{}, "true")
+	_, err := s.WaitJob(context.Background(), record.ID)
+	require.NoError(t, err)
+
+	stopTestJob(t, s, record.ID)
+	s.Lock()
+	defer s.Unlock()
+	require.Empty(t, s.stopping)
+}
+
+// endedStore returns a store holding n jobs that have exited, with ids 1 to n
+func endedStore(t *testing.T, n int) *MemoryStore {
+	store := NewMemoryStore()
+	now := time.Now()
+	for i := 1; i <= n; i++ {
+		require.NoError(t, store.Save(StoredJob{
+			ID:        int32(i),
+			Owner:     "alice",
+			Spec:      jobs.Spec{Command: []string{"true"}},
+			Status:    jobs.StatusExited,
+			StartedAt: now,
+			EndedAt:   now,
+			Attempt:   1,
+		}))
+	}
+	return store
+}
+
+func ids(records []JobRecord) []int32 {
+	var ids []int32
+	for _, record := range records {
+		ids = append(ids, record.ID)
+	}
+	return ids
+}
+
+func Test_Service_ListJobsPages(t *testing.T) {
+	s := newTestService(t, ServiceConfig{Store: endedStore(t, 5)})
+
+	page, token, err := s.ListJobs(context.Background(), ListFilter{PageSize: 2})
+	require.NoError(t, err)
+	require.Equal(t, []int32{1, 2}, ids(page))
+	require.NotEmpty(t, token)
+
+	page, token, err = s.ListJobs(context.Background(), ListFilter{PageSize: 2, PageToken: token})
+	require.NoError(t, err)
+	require.Equal(t, []int32{3, 4}, ids(page))
+	require.NotEmpty(t, token)
+
+	// jobs added between pages are listed on the following pages
+	added := startTestJob(t, s, StartOptions{}, "true")
+	require.Equal(t, int32(6), added.ID)
+
+	page, token, err = s.ListJobs(context.Background(), ListFilter{PageSize: 2, PageToken: token})
+	require.NoError(t, err)
+	require.Equal(t, []int32{5, 6}, ids(page))
+	// the last page has no next page
+	require.Empty(t, token)
+}
+
+func Test_Service_ListJobsLastPage(t *testing.T) {
+	s := newTestService(t, ServiceConfig{Store: endedStore(t, 4)})
+
+	// a page that ends exactly on the last job has no next page
+	page, token, err := s.ListJobs(context.Background(), ListFilter{PageSize: 4})
+	require.NoError(t, err)
+	require.Equal(t, []int32{1, 2, 3, 4}, ids(page))
+	require.Empty(t, token)
+
+	// a token past the last job returns an empty page
+	page, token, err = s.ListJobs(context.Background(), ListFilter{PageToken: encodePageToken(4)})
+	require.NoError(t, err)
+	require.Empty(t, page)
+	require.Empty(t, token)
+}
+
+func Test_Service_ListJobsInvalidPageToken(t *testing.T) {
+	s := newTestService(t, ServiceConfig{Store: endedStore(t, 1)})
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "not base64", token: "!!"},
+		{name: "not an id", token: base64.RawURLEncoding.EncodeToString([]byte("abc"))},
+		{name: "id out of range", token: base64.RawURLEncoding.EncodeToString([]byte("4294967296"))},
+		{name: "padded base64", token: base64.URLEncoding.EncodeToString([]byte("1"))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := s.ListJobs(context.Background(), ListFilter{PageToken: tt.token})
+			require.ErrorIs(t, err, ErrInvalidPageToken)
+		})
+	}
+}
+
+func Test_Service_OutputBudget(t *testing.T) {
+	s := newTestService(t, ServiceConfig{OutputBudget: 1000})
+	start := func(limit int64, opts StartOptions, command ...string) (JobRecord, error) {
+		return s.StartJob(context.Background(), "alice", jobs.Spec{Command: command, Limits: testLimits, OutputLimit: limit}, opts)
+	}
+
+	// jobs that have not ended reserve their limit for stdout and stderr of each attempt they may still run
+	retried, err := start(100, StartOptions{Retry: RetryPolicy{MaxAttempts: 2}}, "sleep", "5")
+	require.NoError(t, err)
+	require.Equal(t, int64(400), outputReserved(retried))
+	running, err := start(300, StartOptions{}, "sleep", "5")
+	require.NoError(t, err)
+	_, err = start(1, StartOptions{}, "true")
+	require.ErrorIs(t, err, ErrOutputBudget)
+	_, err = start(0, StartOptions{}, "true")
+	require.ErrorIs(t, err, ErrOutputBudget)
+
+	// jobs that have ended count the output they keep
+	stopTestJob(t, s, running.ID)
+	ended, err := start(100, StartOptions{}, "echo", "hello")
+	require.NoError(t, err)
+	record, err := s.WaitJob(context.Background(), ended.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(len("hello\n")), outputReserved(record))
+	stopTestJob(t, s, retried.ID)
+}
@@ -0,0 +1,460 @@
+package jobs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"job_runner/pkg/cgroupz"
+	"job_runner/pkg/jobs"
+)
+
+// Store persists the jobs of a Service so their history survives restarts
+type Store interface {
+	// Save creates or replaces the stored job with the same id
+	Save(job StoredJob) error
+	// List returns every stored job ordered by id
+	List() ([]StoredJob, error)
+	// Delete removes the stored job, deleting a job that is not stored is not an error
+	Delete(id int32) error
+	// MaxID returns the highest id saved, including the ids of deleted jobs, so ids are not reused
+	MaxID() (int32, error)
+
+	// SaveSchedule creates or replaces the stored schedule with the same id
+	SaveSchedule(schedule Schedule) error
+	// ListSchedules returns every stored schedule ordered by id
+	ListSchedules() ([]Schedule, error)
+	// DeleteSchedule removes the stored schedule, deleting a schedule that is not stored is not an error
+	DeleteSchedule(id int32) error
+	// MaxScheduleID returns the highest schedule id saved, including the ids of deleted schedules
+	MaxScheduleID() (int32, error)
+
+	// SaveWorkflow creates or replaces the stored workflow with the same id
+	SaveWorkflow(workflow Workflow) error
+	// ListWorkflows returns every stored workflow ordered by id
+	ListWorkflows() ([]Workflow, error)
+}
+
+// StoredJob is the persisted form of a JobRecord. Spec.Env is stored as is, so secrets passed to a job through its
+// environment are readable by anyone who can read the store.
+type StoredJob struct {
+	ID    int32     `json:"id"`
+	Owner string    `json:"owner"`
+	Spec  jobs.Spec `json:"spec"`
+	// Options is how the job is scheduled
+	Options StartOptions `json:"options"`
+
+	Status    jobs.Status    `json:"status"`
+	ExitCode  int            `json:"exit_code"`
+	Signal    syscall.Signal `json:"signal,omitempty"`
+	StartedAt time.Time      `json:"started_at"`
+	EndedAt   time.Time      `json:"ended_at"`
+
+	OutputTruncated int64                `json:"output_truncated,omitempty"`
+	Usage           jobs.Usage           `json:"usage"`
+	MemoryEvents    cgroupz.MemoryEvents `json:"memory_events"`
+	// Summary is set once the output of the job has been removed
+	Summary bool `json:"summary,omitempty"`
+	// Attempt is the number of the current attempt, Attempts holds the earlier attempts
+	Attempt  int       `json:"attempt,omitempty"`
+	Attempts []Attempt `json:"attempts,omitempty"`
+	// RetryAt is when a queued attempt waiting for its backoff to pass is admitted
+	RetryAt time.Time `json:"retry_at"`
+	// Transitions holds every status the job has had, across its attempts, in the order it had them
+	Transitions []StatusTransition `json:"transitions,omitempty"`
+}
+
+// StatusTransition is a status a job entered and when it did
+type StatusTransition struct {
+	Status jobs.Status `json:"status"`
+	Time   time.Time   `json:"time"`
+}
+
+func toStoredJob(record JobRecord) StoredJob {
+	state := record.Job.State()
+	return StoredJob{
+		ID:        record.ID,
+		Owner:     record.Owner,
+		Spec:      record.Job.Spec(),
+		Options:   record.StartOptions,
+		Status:    state.Status,
+		ExitCode:  state.ExitCode,
+		Signal:    state.Signal,
+		StartedAt: state.StartedAt,
+		EndedAt:   state.EndedAt,
+
+		OutputTruncated: state.OutputTruncated,
+		Usage:           state.Usage,
+		MemoryEvents:    state.MemoryEvents,
+		Summary:         record.Summary,
+		Attempt:         record.Attempt,
+		Attempts:        record.Attempts,
+		RetryAt:         record.RetryAt,
+	}
+}
+
+func (s StoredJob) state() jobs.State {
+	return jobs.State{
+		Status:    s.Status,
+		ExitCode:  s.ExitCode,
+		Signal:    s.Signal,
+		StartedAt: s.StartedAt,
+		EndedAt:   s.EndedAt,
+
+		OutputTruncated: s.OutputTruncated,
+		Usage:           s.Usage,
+		MemoryEvents:    s.MemoryEvents,
+	}
+}
+
+// MemoryStore keeps jobs in memory, they are lost when the server stops
+type MemoryStore struct {
+	mu            sync.Mutex
+	jobs          map[int32]StoredJob
+	maxID         int32
+	schedules     map[int32]Schedule
+	maxScheduleID int32
+	workflows     map[int32]Workflow
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		jobs:      make(map[int32]StoredJob),
+		schedules: make(map[int32]Schedule),
+		workflows: make(map[int32]Workflow),
+	}
+}
+
+func (m *MemoryStore) Save(job StoredJob) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.jobs[job.ID] = job
+	if job.ID > m.maxID {
+		m.maxID = job.ID
+	}
+	return nil
+}
+
+func (m *MemoryStore) List() ([]StoredJob, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stored := make([]StoredJob, 0, len(m.jobs))
+	for _, job := range m.jobs {
+		stored = append(stored, job)
+	}
+	sort.Slice(stored, func(i, j int) bool { return stored[i].ID < stored[j].ID })
+	return stored, nil
+}
+
+func (m *MemoryStore) Delete(id int32) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.jobs, id)
+	return nil
+}
+
+func (m *MemoryStore) MaxID() (int32, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.maxID, nil
+}
+
+func (m *MemoryStore) SaveSchedule(schedule Schedule) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.schedules[schedule.ID] = schedule
+	if schedule.ID > m.maxScheduleID {
+		m.maxScheduleID = schedule.ID
+	}
+	return nil
+}
+
+func (m *MemoryStore) ListSchedules() ([]Schedule, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stored := make([]Schedule, 0, len(m.schedules))
+	for _, schedule := range m.schedules {
+		stored = append(stored, schedule)
+	}
+	sort.Slice(stored, func(i, j int) bool { return stored[i].ID < stored[j].ID })
+	return stored, nil
+}
+
+func (m *MemoryStore) DeleteSchedule(id int32) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.schedules, id)
+	return nil
+}
+
+func (m *MemoryStore) MaxScheduleID() (int32, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.maxScheduleID, nil
+}
+
+func (m *MemoryStore) SaveWorkflow(workflow Workflow) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.workflows[workflow.ID] = workflow.copy()
+	return nil
+}
+
+func (m *MemoryStore) ListWorkflows() ([]Workflow, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stored := make([]Workflow, 0, len(m.workflows))
+	for _, workflow := range m.workflows {
+		stored = append(stored, workflow.copy())
+	}
+	sort.Slice(stored, func(i, j int) bool { return stored[i].ID < stored[j].ID })
+	return stored, nil
+}
+
+// FileStore keeps each job in its own json file in a directory. Files are replaced atomically, so a crash
+// leaves either the previous or the new version of a job. The directory and files are only accessible by the user
+// the server runs as, as they hold the environment of every job in plain text. The highest job and schedule ids saved are kept in files
+// of their own, schedules and workflows are kept the same way as jobs in subdirectories of their own.
+type FileStore struct {
+	dir string
+
+	mu            sync.Mutex
+	maxID         int32
+	maxScheduleID int32
+}
+
+// NewFileStore returns a FileStore keeping jobs in dir, which is created if it does not exist
+func NewFileStore(dir string) (*FileStore, error) {
+	for _, sub := range []string{schedulesDir, workflowsDir} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0700); err != nil {
+			return nil, fmt.Errorf("os.MkdirAll: %w", err)
+		}
+	}
+	f := &FileStore{dir: dir}
+	var err error
+	if f.maxID, err = readMaxID(filepath.Join(dir, maxIDFile)); err != nil {
+		return nil, err
+	}
+	if f.maxScheduleID, err = readMaxID(filepath.Join(dir, maxScheduleIDFile)); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// readMaxID reads a file holding the highest id saved, 0 if it does not exist
+func readMaxID(path string) (int32, error) {
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return 0, fmt.Errorf("os.ReadFile: %w", err)
+	}
+	if len(data) == 0 {
+		return 0, nil
+	}
+	id, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("parse %s: %w", filepath.Base(path), err)
+	}
+	return int32(id), nil
+}
+
+const (
+	// maxIDFile holds the highest job id saved in a FileStore
+	maxIDFile = "max_id"
+	// maxScheduleIDFile holds the highest schedule id saved in a FileStore
+	maxScheduleIDFile = "max_schedule_id"
+	// schedulesDir is the subdirectory of a FileStore holding schedules
+	schedulesDir = "schedules"
+	// workflowsDir is the subdirectory of a FileStore holding workflows
+	workflowsDir = "workflows"
+)
+
+func (f *FileStore) path(id int32) string {
+	return filepath.Join(f.dir, strconv.Itoa(int(id))+".json")
+}
+
+func (f *FileStore) schedulePath(id int32) string {
+	return filepath.Join(f.dir, schedulesDir, strconv.Itoa(int(id))+".json")
+}
+
+func (f *FileStore) workflowPath(id int32) string {
+	return filepath.Join(f.dir, workflowsDir, strconv.Itoa(int(id))+".json")
+}
+
+func (f *FileStore) Save(job StoredJob) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("json.Marshal: %w", err)
+	}
+	if err := f.writeFile(f.path(job.ID), data); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if job.ID > f.maxID {
+		if err := f.writeFile(filepath.Join(f.dir, maxIDFile), []byte(strconv.Itoa(int(job.ID)))); err != nil {
+			return err
+		}
+		f.maxID = job.ID
+	}
+	return nil
+}
+
+func (f *FileStore) MaxID() (int32, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.maxID, nil
+}
+
+// writeFile replaces the file at path with data atomically
+func (f *FileStore) writeFile(path string, data []byte) error {
+	tmp, err := os.CreateTemp(f.dir, ".job-*")
+	if err != nil {
+		return fmt.Errorf("os.CreateTemp: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("sync: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("os.Rename: %w", err)
+	}
+	return nil
+}
+
+func (f *FileStore) List() ([]StoredJob, error) {
+	var stored []StoredJob
+	err := readJSONFiles(f.dir, func(name string, data []byte) error {
+		var job StoredJob
+		if err := json.Unmarshal(data, &job); err != nil {
+			return fmt.Errorf("json.Unmarshal %s: %w", name, err)
+		}
+		stored = append(stored, job)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(stored, func(i, j int) bool { return stored[i].ID < stored[j].ID })
+	return stored, nil
+}
+
+func (f *FileStore) Delete(id int32) error {
+	if err := os.Remove(f.path(id)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("os.Remove: %w", err)
+	}
+	return nil
+}
+
+func (f *FileStore) SaveSchedule(schedule Schedule) error {
+	data, err := json.Marshal(schedule)
+	if err != nil {
+		return fmt.Errorf("json.Marshal: %w", err)
+	}
+	if err := f.writeFile(f.schedulePath(schedule.ID), data); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if schedule.ID > f.maxScheduleID {
+		if err := f.writeFile(filepath.Join(f.dir, maxScheduleIDFile), []byte(strconv.Itoa(int(schedule.ID)))); err != nil {
+			return err
+		}
+		f.maxScheduleID = schedule.ID
+	}
+	return nil
+}
+
+func (f *FileStore) MaxScheduleID() (int32, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.maxScheduleID, nil
+}
+
+func (f *FileStore) ListSchedules() ([]Schedule, error) {
+	var stored []Schedule
+	err := readJSONFiles(filepath.Join(f.dir, schedulesDir), func(name string, data []byte) error {
+		var schedule Schedule
+		if err := json.Unmarshal(data, &schedule); err != nil {
+			return fmt.Errorf("json.Unmarshal %s: %w", name, err)
+		}
+		stored = append(stored, schedule)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(stored, func(i, j int) bool { return stored[i].ID < stored[j].ID })
+	return stored, nil
+}
+
+func (f *FileStore) DeleteSchedule(id int32) error {
+	if err := os.Remove(f.schedulePath(id)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("os.Remove: %w", err)
+	}
+	return nil
+}
+
+func (f *FileStore) SaveWorkflow(workflow Workflow) error {
+	data, err := json.Marshal(workflow)
+	if err != nil {
+		return fmt.Errorf("json.Marshal: %w", err)
+	}
+	return f.writeFile(f.workflowPath(workflow.ID), data)
+}
+
+func (f *FileStore) ListWorkflows() ([]Workflow, error) {
+	var stored []Workflow
+	err := readJSONFiles(filepath.Join(f.dir, workflowsDir), func(name string, data []byte) error {
+		var workflow Workflow
+		if err := json.Unmarshal(data, &workflow); err != nil {
+			return fmt.Errorf("json.Unmarshal %s: %w", name, err)
+		}
+		stored = append(stored, workflow)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(stored, func(i, j int) bool { return stored[i].ID < stored[j].ID })
+	return stored, nil
+}
+
+// readJSONFiles calls fn with the name and content of every json file in dir
+func readJSONFiles(dir string, fn func(name string, data []byte) error) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("os.ReadDir: %w", err)
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || strings.HasPrefix(name, ".") || filepath.Ext(name) != ".json" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return fmt.Errorf("os.ReadFile: %w", err)
+		}
+		if err := fn(name, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
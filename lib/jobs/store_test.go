@@ -0,0 +1,206 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"job_runner/pkg/cgroupz"
+	"job_runner/pkg/jobs"
+)
+
+func storedJob(id int32, status jobs.Status) StoredJob {
+	started := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	job := StoredJob{
+		ID:        id,
+		Owner:     "alice",
+		Spec:      jobs.Spec{Command: []string{"echo", "hello"}, Limits: testLimits, Env: []string{"A=1"}},
+		Options:   StartOptions{Priority: 2, Retry: RetryPolicy{MaxAttempts: 3, Signals: []syscall.Signal{syscall.SIGKILL}}},
+		Status:    status,
+		ExitCode:  -1,
+		StartedAt: started,
+		Attempt:   1,
+
+		Transitions: []StatusTransition{{Status: jobs.StatusRunning, Time: started}},
+	}
+	if status != jobs.StatusRunning {
+		job.ExitCode = 0
+		job.EndedAt = started.Add(time.Second)
+		job.Usage = jobs.Usage{CPU: time.Second, PeakMemory: 1 << 20}
+		job.MemoryEvents = cgroupz.MemoryEvents{Max: 1}
+		job.Transitions = append(job.Transitions, StatusTransition{Status: status, Time: job.EndedAt})
+	}
+	return job
+}
+
+func transitionStatuses(transitions []StatusTransition) []jobs.Status {
+	var statuses []jobs.Status
+	for _, transition := range transitions {
+		statuses = append(statuses, transition.Status)
+	}
+	return statuses
+}
+
+func Test_FileStore_RoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewFileStore(dir)
+	require.NoError(t, err)
+	for _, sub := range []string{schedulesDir, workflowsDir} {
+		info, err := os.Stat(filepath.Join(dir, sub))
+		require.NoError(t, err)
+		require.True(t, info.IsDir())
+	}
+
+	exited := storedJob(1, jobs.StatusExited)
+	exited.Attempt = 2
+	exited.Attempts = []Attempt{{Number: 1, Status: jobs.StatusStopped, ExitCode: -1, Signal: syscall.SIGKILL, OutputDir: "/tmp/1"}}
+	running := storedJob(2, jobs.StatusRunning)
+	deleted := storedJob(5, jobs.StatusExited)
+	for _, job := range []StoredJob{exited, running, deleted} {
+		require.NoError(t, store.Save(job))
+	}
+	// saving the same id replaces the job
+	running.Options.Priority = 7
+	require.NoError(t, store.Save(running))
+	require.NoError(t, store.Delete(deleted.ID))
+	require.NoError(t, store.Delete(42))
+
+	schedule := Schedule{ID: 3, Owner: "alice", Cron: "*/5 * * * *", Timezone: "UTC", Spec: exited.Spec, Concurrency: ConcurrencyForbid, History: []int32{1, 2}}
+	require.NoError(t, store.SaveSchedule(schedule))
+	require.NoError(t, store.SaveSchedule(Schedule{ID: 4, Cron: "@daily"}))
+	require.NoError(t, store.DeleteSchedule(4))
+	workflow := Workflow{ID: 1, Owner: "alice", Status: WorkflowRunning, Nodes: []WorkflowNode{{Name: "a", Status: NodeRunning, JobID: 2, Condition: ConditionOnSuccess}}}
+	require.NoError(t, store.SaveWorkflow(workflow))
+
+	reopened, err := NewFileStore(dir)
+	require.NoError(t, err)
+	stored, err := reopened.List()
+	require.NoError(t, err)
+	require.Equal(t, []StoredJob{exited, running}, stored)
+	// the id of a deleted job is not reused
+	maxID, err := reopened.MaxID()
+	require.NoError(t, err)
+	require.Equal(t, int32(5), maxID)
+
+	schedules, err := reopened.ListSchedules()
+	require.NoError(t, err)
+	require.Equal(t, []Schedule{schedule}, schedules)
+	maxScheduleID, err := reopened.MaxScheduleID()
+	require.NoError(t, err)
+	require.Equal(t, int32(4), maxScheduleID)
+	workflows, err := reopened.ListWorkflows()
+	require.NoError(t, err)
+	require.Equal(t, []Workflow{workflow}, workflows)
+}
+
+func Test_FileStore_AtomicSave(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewFileStore(dir)
+	require.NoError(t, err)
+
+	job := storedJob(1, jobs.StatusRunning)
+	require.NoError(t, store.Save(job))
+	job.Status = jobs.StatusExited
+	require.NoError(t, store.Save(job))
+
+	// the temporary files saves are written to are renamed over the job's file
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	for _, entry := range entries {
+		require.False(t, strings.HasPrefix(entry.Name(), ".job-"), entry.Name())
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "1.json"))
+	require.NoError(t, err)
+	var saved StoredJob
+	require.NoError(t, json.Unmarshal(data, &saved))
+	require.Equal(t, job, saved)
+
+	// a temporary file left over by a crash mid save and files that are not jobs are ignored
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".job-123"), []byte(`{"id": 1, "status": "tru`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README"), []byte("not a job"), 0600))
+	stored, err := store.List()
+	require.NoError(t, err)
+	require.Equal(t, []StoredJob{job}, stored)
+}
+
+func Test_FileStore_CorruptMaxID(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, maxIDFile), []byte("ten"), 0600))
+	_, err := NewFileStore(dir)
+	require.Error(t, err)
+}
+
+func Test_Service_RestoreRunningJobsAsLost(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewFileStore(dir)
+	require.NoError(t, err)
+	require.NoError(t, store.Save(storedJob(1, jobs.StatusExited)))
+	require.NoError(t, store.Save(storedJob(2, jobs.StatusRunning)))
+	require.NoError(t, store.Save(storedJob(5, jobs.StatusExited)))
+	require.NoError(t, store.Delete(5))
+
+	s := newTestService(t, ServiceConfig{Store: store})
+	exited, err := s.GetJob(context.Background(), 1)
+	require.NoError(t, err)
+	require.Equal(t, jobs.StatusExited, exited.Job.State().Status)
+
+	// the process of a running job did not survive the restart
+	lost, err := s.GetJob(context.Background(), 2)
+	require.NoError(t, err)
+	state := lost.Job.State()
+	require.Equal(t, jobs.StatusLost, state.Status)
+	require.Equal(t, -1, state.ExitCode)
+	require.False(t, state.EndedAt.IsZero())
+	_, err = s.WaitJob(context.Background(), 2)
+	require.NoError(t, err)
+
+	// and it is stored as lost
+	reopened, err := NewFileStore(dir)
+	require.NoError(t, err)
+	stored, err := reopened.List()
+	require.NoError(t, err)
+	require.Len(t, stored, 2)
+	require.Equal(t, jobs.StatusLost, stored[1].Status)
+	require.Equal(t, []jobs.Status{jobs.StatusRunning, jobs.StatusLost}, transitionStatuses(stored[1].Transitions))
+	require.Equal(t, stored[1].EndedAt, stored[1].Transitions[1].Time)
+
+	// new jobs are numbered after the highest id saved, including deleted jobs
+	require.Equal(t, int32(6), startTestJob(t, s, StartOptions{}, "true").ID)
+}
+
+func Test_Service_StatusTransitions(t *testing.T) {
+	store := NewMemoryStore()
+	s := newTestService(t, ServiceConfig{Store: store})
+	policy := RetryPolicy{MaxAttempts: 2, InitialBackoff: 10 * time.Millisecond}
+	record := startTestJob(t, s, StartOptions{Retry: policy}, "false")
+	ended, err := s.WaitJob(context.Background(), record.ID)
+	require.NoError(t, err)
+
+	stored, err := store.List()
+	require.NoError(t, err)
+	require.Len(t, stored, 1)
+	// every attempt is recorded, the next attempt is queued while it waits for its backoff
+	transitions := stored[0].Transitions
+	expected := []jobs.Status{jobs.StatusRunning, jobs.StatusExited, jobs.StatusQueued, jobs.StatusRunning, jobs.StatusExited}
+	require.Equal(t, expected, transitionStatuses(transitions))
+	for i := 1; i < len(transitions); i++ {
+		require.False(t, transitions[i].Time.Before(transitions[i-1].Time))
+	}
+	state := ended.Job.State()
+	require.Equal(t, state.StartedAt, transitions[3].Time)
+	require.Equal(t, state.EndedAt, transitions[4].Time)
+
+	// the transitions are kept across restarts
+	restarted := newTestService(t, ServiceConfig{Store: store})
+	restarted.save(ended)
+	stored, err = store.List()
+	require.NoError(t, err)
+	require.Equal(t, expected, transitionStatuses(stored[0].Transitions))
+}
@@ -0,0 +1,73 @@
+package jobs
+
+import (
+	"errors"
+	"fmt"
+	"os/user"
+	"strconv"
+	"strings"
+
+	"job_runner/pkg/jobs"
+)
+
+// ErrInvalidUser is wrapped by the errors returned when the user or group a job should run as can not be resolved
+var ErrInvalidUser = errors.New("invalid user")
+
+// LookupCredential resolves a user given as "user" or "user:group" to the ids a job runs as. The user and group are
+// names or numeric ids. When the group is omitted, the primary group of the user is used, which requires the user to
+// exist on the host.
+func LookupCredential(s string) (*jobs.Credential, error) {
+	name, group, hasGroup := strings.Cut(s, ":")
+	if name == "" || (hasGroup && group == "") {
+		return nil, fmt.Errorf("%w: %q must be user or user:group", ErrInvalidUser, s)
+	}
+
+	var cred jobs.Credential
+	uid, err := strconv.ParseUint(name, 10, 32)
+	if err == nil && hasGroup {
+		// a numeric uid does not need to exist on the host when the group is given
+		cred.UID = uint32(uid)
+	} else {
+		u, err := lookupUser(name)
+		if err != nil {
+			return nil, err
+		}
+		id, err := strconv.ParseUint(u.Uid, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("%w: uid %q of %s: %v", ErrInvalidUser, u.Uid, name, err)
+		}
+		cred.UID = uint32(id)
+		if !hasGroup {
+			group = u.Gid
+		}
+	}
+
+	gid, err := strconv.ParseUint(group, 10, 32)
+	if err != nil {
+		g, err := user.LookupGroup(group)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidUser, err)
+		}
+		gid, err = strconv.ParseUint(g.Gid, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("%w: gid %q of %s: %v", ErrInvalidUser, g.Gid, group, err)
+		}
+	}
+	cred.GID = uint32(gid)
+	return &cred, nil
+}
+
+// lookupUser finds a user by numeric id or by name
+func lookupUser(name string) (*user.User, error) {
+	var u *user.User
+	var err error
+	if _, perr := strconv.ParseUint(name, 10, 32); perr == nil {
+		u, err = user.LookupId(name)
+	} else {
+		u, err = user.Lookup(name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidUser, err)
+	}
+	return u, nil
+}
@@ -0,0 +1,469 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"syscall"
+	"time"
+
+	"job_runner/pkg/jobs"
+)
+
+// ErrWorkflowNotFound is returned when no workflow has the requested id
+var ErrWorkflowNotFound = errors.New("workflow not found")
+
+// ErrInvalidWorkflow is returned when starting a workflow whose nodes do not form a valid graph
+var ErrInvalidWorkflow = errors.New("invalid workflow")
+
+// maxWorkflowNodes is the number of nodes a workflow can have
+const maxWorkflowNodes = 100
+
+// NodeCondition decides whether a node runs once the nodes it depends on have ended
+type NodeCondition string
+
+const (
+	// ConditionOnSuccess runs the node if every dependency succeeded
+	ConditionOnSuccess NodeCondition = "on_success"
+	// ConditionOnFailure runs the node if at least one dependency failed
+	ConditionOnFailure NodeCondition = "on_failure"
+	// ConditionAlways runs the node regardless of how its dependencies ended
+	ConditionAlways NodeCondition = "always"
+)
+
+// NodeStatus is the status of a node of a workflow
+type NodeStatus string
+
+const (
+	NodePending NodeStatus = "pending"
+	NodeRunning NodeStatus = "running"
+	// NodeSucceeded is a node whose job exited with code 0
+	NodeSucceeded NodeStatus = "succeeded"
+	// NodeFailed is a node whose job ended any other way or could not be started
+	NodeFailed NodeStatus = "failed"
+	// NodeSkipped is a node whose condition did not hold once its dependencies ended
+	NodeSkipped NodeStatus = "skipped"
+	// NodeCancelled is a node that was stopped or never started because the workflow was stopped
+	NodeCancelled NodeStatus = "cancelled"
+)
+
+func (s NodeStatus) ended() bool {
+	return s != NodePending && s != NodeRunning
+}
+
+// WorkflowStatus is the overall status of a workflow
+type WorkflowStatus string
+
+const (
+	WorkflowRunning WorkflowStatus = "running"
+	// WorkflowSucceeded is a workflow that ended without any failed node
+	WorkflowSucceeded WorkflowStatus = "succeeded"
+	// WorkflowFailed is a workflow that ended with at least one failed node, even if a node handled the failure
+	WorkflowFailed WorkflowStatus = "failed"
+	// WorkflowCancelled is a workflow that was stopped
+	WorkflowCancelled WorkflowStatus = "cancelled"
+)
+
+// WorkflowNode is a job of a workflow along with the nodes it depends on
+type WorkflowNode struct {
+	Name      string        `json:"name"`
+	Spec      jobs.Spec     `json:"spec"`
+	Options   StartOptions  `json:"options"`
+	DependsOn []string      `json:"depends_on,omitempty"`
+	Condition NodeCondition `json:"condition"`
+
+	Status NodeStatus `json:"status"`
+	// JobID is set once the job of the node was started
+	JobID int32 `json:"job_id,omitempty"`
+}
+
+// Workflow is a graph of jobs started on behalf of its owner. A node is started once every node it depends on
+// has ended and its condition holds, otherwise it is skipped. The workflow ends once every node has ended.
+type Workflow struct {
+	ID        int32          `json:"id"`
+	Owner     string         `json:"owner"`
+	Nodes     []WorkflowNode `json:"nodes"`
+	Status    WorkflowStatus `json:"status"`
+	CreatedAt time.Time      `json:"created_at"`
+	EndedAt   time.Time      `json:"ended_at,omitempty"`
+}
+
+// copy returns the workflow with its own nodes, so it can be returned to callers outside the lock
+func (w Workflow) copy() Workflow {
+	w.Nodes = append([]WorkflowNode(nil), w.Nodes...)
+	return w
+}
+
+// validate checks that the node names are unique, that dependencies exist and that the graph has no cycle
+func (w *Workflow) validate() error {
+	if len(w.Nodes) == 0 {
+		return fmt.Errorf("%w: a workflow needs at least one node", ErrInvalidWorkflow)
+	}
+	if len(w.Nodes) > maxWorkflowNodes {
+		return fmt.Errorf("%w: a workflow can have at most %d nodes", ErrInvalidWorkflow, maxWorkflowNodes)
+	}
+	index := make(map[string]int, len(w.Nodes))
+	for i, node := range w.Nodes {
+		if node.Name == "" {
+			return fmt.Errorf("%w: node %d has no name", ErrInvalidWorkflow, i)
+		}
+		if _, ok := index[node.Name]; ok {
+			return fmt.Errorf("%w: node name %q is used twice", ErrInvalidWorkflow, node.Name)
+		}
+		index[node.Name] = i
+		switch node.Condition {
+		case "":
+			w.Nodes[i].Condition = ConditionOnSuccess
+		case ConditionOnSuccess, ConditionOnFailure, ConditionAlways:
+		default:
+			return fmt.Errorf("%w: unknown condition %q of node %q", ErrInvalidWorkflow, node.Condition, node.Name)
+		}
+	}
+	for _, node := range w.Nodes {
+		for _, dep := range node.DependsOn {
+			if _, ok := index[dep]; !ok {
+				return fmt.Errorf("%w: node %q depends on unknown node %q", ErrInvalidWorkflow, node.Name, dep)
+			}
+			if dep == node.Name {
+				return fmt.Errorf("%w: node %q depends on itself", ErrInvalidWorkflow, node.Name)
+			}
+		}
+	}
+
+	// nodes are removed once their dependencies are, the nodes left over form a cycle
+	remaining := make([]int, len(w.Nodes))
+	dependents := make(map[string][]int)
+	var ready []int
+	for i, node := range w.Nodes {
+		remaining[i] = len(node.DependsOn)
+		if remaining[i] == 0 {
+			ready = append(ready, i)
+		}
+		for _, dep := range node.DependsOn {
+			dependents[dep] = append(dependents[dep], i)
+		}
+	}
+	visited := 0
+	for len(ready) > 0 {
+		i := ready[0]
+		ready = ready[1:]
+		visited++
+		for _, j := range dependents[w.Nodes[i].Name] {
+			remaining[j]--
+			if remaining[j] == 0 {
+				ready = append(ready, j)
+			}
+		}
+	}
+	if visited != len(w.Nodes) {
+		return fmt.Errorf("%w: the dependencies of the nodes form a cycle", ErrInvalidWorkflow)
+	}
+	return nil
+}
+
+// decide returns whether a pending node runs, is skipped, or waits for its dependencies to end
+func (w Workflow) decide(node WorkflowNode) (NodeStatus, bool) {
+	statuses := make(map[string]NodeStatus, len(w.Nodes))
+	for _, n := range w.Nodes {
+		statuses[n.Name] = n.Status
+	}
+	succeeded, failed := true, false
+	for _, dep := range node.DependsOn {
+		switch statuses[dep] {
+		case NodePending, NodeRunning:
+			return NodePending, false
+		case NodeSucceeded:
+		case NodeFailed:
+			succeeded, failed = false, true
+		default:
+			succeeded = false
+		}
+	}
+	switch {
+	case node.Condition == ConditionAlways,
+		node.Condition == ConditionOnSuccess && succeeded,
+		node.Condition == ConditionOnFailure && failed:
+		return NodeRunning, true
+	}
+	return NodeSkipped, true
+}
+
+// restoreWorkflows loads the stored workflows and resumes those that had not ended. Nodes whose job did not
+// survive the restart have failed.
+func (s *Service) restoreWorkflows() error {
+	stored, err := s.store.ListWorkflows()
+	if err != nil {
+		return fmt.Errorf("store.ListWorkflows: %w", err)
+	}
+	var resumed []Workflow
+	s.wfMu.Lock()
+	for _, workflow := range stored {
+		if workflow.ID > s.workflowID {
+			s.workflowID = workflow.ID
+		}
+		s.workflows[workflow.ID] = workflow
+		if workflow.Status == WorkflowRunning {
+			resumed = append(resumed, workflow)
+		}
+	}
+	s.wfMu.Unlock()
+
+	for _, workflow := range resumed {
+		for _, node := range workflow.Nodes {
+			if node.Status != NodeRunning {
+				continue
+			}
+			if _, err := s.GetJob(s.parentCtx, node.JobID); err != nil {
+				s.nodeEnded(workflow.ID, node.Name, NodeFailed)
+				continue
+			}
+			s.watchNode(workflow.ID, node.Name, node.JobID)
+		}
+		s.advance(workflow.ID)
+	}
+	return nil
+}
+
+// saveWorkflow persists the workflow, failures are logged as the jobs of the workflow are unaffected
+func (s *Service) saveWorkflow(workflow Workflow) {
+	if err := s.store.SaveWorkflow(workflow); err != nil {
+		fmt.Printf("error saving workflow with id %d: %v\n", workflow.ID, err)
+	}
+}
+
+// StartWorkflow validates the graph of the workflow and starts the nodes without dependencies on behalf of owner.
+// The id, statuses and creation time are set by the service.
+func (s *Service) StartWorkflow(ctx context.Context, workflow Workflow) (Workflow, error) {
+	workflow.Nodes = append([]WorkflowNode(nil), workflow.Nodes...)
+	if err := workflow.validate(); err != nil {
+		return Workflow{}, err
+	}
+	for i := range workflow.Nodes {
+		workflow.Nodes[i].Status = NodePending
+		workflow.Nodes[i].JobID = 0
+	}
+	workflow.Status = WorkflowRunning
+	workflow.CreatedAt = time.Now()
+	workflow.EndedAt = time.Time{}
+
+	s.wfMu.Lock()
+	s.workflowID++
+	workflow.ID = s.workflowID
+	if err := s.store.SaveWorkflow(workflow); err != nil {
+		s.wfMu.Unlock()
+		return Workflow{}, fmt.Errorf("store.SaveWorkflow: %w", err)
+	}
+	s.workflows[workflow.ID] = workflow
+	s.wfMu.Unlock()
+
+	s.advance(workflow.ID)
+	return s.GetWorkflow(ctx, workflow.ID)
+}
+
+// advance starts or skips the pending nodes whose dependencies have ended, until no node changes,
+// and ends the workflow once every node has ended
+func (s *Service) advance(id int32) {
+	for {
+		s.wfMu.Lock()
+		workflow, ok := s.workflows[id]
+		if !ok || workflow.Status != WorkflowRunning || s.parentCtx.Err() != nil {
+			s.wfMu.Unlock()
+			return
+		}
+		var start []WorkflowNode
+		changed := false
+		for i, node := range workflow.Nodes {
+			if node.Status != NodePending {
+				continue
+			}
+			status, ok := workflow.decide(node)
+			if !ok {
+				continue
+			}
+			// nodes are marked as running before their job starts, so they are started once
+			workflow.Nodes[i].Status = status
+			changed = true
+			if status == NodeRunning {
+				start = append(start, node)
+			}
+		}
+		if !changed {
+			s.endWorkflow(&workflow)
+			s.workflows[id] = workflow
+			s.wfMu.Unlock()
+			return
+		}
+		s.workflows[id] = workflow
+		s.saveWorkflow(workflow)
+		owner := workflow.Owner
+		s.wfMu.Unlock()
+
+		for _, node := range start {
+			record, err := s.StartJob(s.parentCtx, owner, node.Spec, node.Options)
+			if err != nil {
+				fmt.Printf("workflow %d error starting node %q: %v\n", id, node.Name, err)
+				s.nodeEnded(id, node.Name, NodeFailed)
+				continue
+			}
+			if stopped := s.setNodeJob(id, node.Name, record.ID); stopped {
+				// the workflow was stopped while the job was starting
+				if _, err := s.StopJob(s.parentCtx, record.ID, syscall.SIGTERM, defaultStopGrace); err != nil {
+					fmt.Printf("workflow %d error stopping job %d: %v\n", id, record.ID, err)
+				}
+			}
+			s.watchNode(id, node.Name, record.ID)
+		}
+	}
+}
+
+// endWorkflow sets the overall status once every node has ended, the caller must hold the lock
+func (s *Service) endWorkflow(workflow *Workflow) {
+	status := WorkflowSucceeded
+	for _, node := range workflow.Nodes {
+		if !node.Status.ended() {
+			return
+		}
+		if node.Status == NodeFailed {
+			status = WorkflowFailed
+		}
+	}
+	workflow.Status = status
+	workflow.EndedAt = time.Now()
+	s.saveWorkflow(*workflow)
+}
+
+// setNodeJob records the job started for the node and reports whether the workflow was stopped in the meantime
+func (s *Service) setNodeJob(id int32, name string, jobID int32) bool {
+	s.wfMu.Lock()
+	defer s.wfMu.Unlock()
+	workflow := s.workflows[id]
+	for i := range workflow.Nodes {
+		if workflow.Nodes[i].Name == name {
+			workflow.Nodes[i].JobID = jobID
+		}
+	}
+	s.saveWorkflow(workflow)
+	return workflow.Status == WorkflowCancelled
+}
+
+// watchNode waits in the background for the job of the node to end and moves the workflow on
+func (s *Service) watchNode(id int32, name string, jobID int32) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		record, err := s.WaitJob(s.parentCtx, jobID)
+		if err != nil {
+			// the service is shutting down, the workflow is resumed on the next start
+			return
+		}
+		s.nodeEnded(id, name, nodeStatus(record.Job.State()))
+		s.advance(id)
+	}()
+}
+
+// nodeStatus returns the status of a node whose job ended in state
+func nodeStatus(state jobs.State) NodeStatus {
+	switch {
+	case state.Status == jobs.StatusExited && state.ExitCode == 0:
+		return NodeSucceeded
+	case state.Status == jobs.StatusCancelled:
+		return NodeCancelled
+	}
+	return NodeFailed
+}
+
+// nodeEnded records how the node ended, a node that did not succeed in a stopped workflow was cancelled
+func (s *Service) nodeEnded(id int32, name string, status NodeStatus) {
+	s.wfMu.Lock()
+	defer s.wfMu.Unlock()
+	workflow := s.workflows[id]
+	if workflow.Status == WorkflowCancelled && status != NodeSucceeded {
+		status = NodeCancelled
+	}
+	for i := range workflow.Nodes {
+		if workflow.Nodes[i].Name == name {
+			workflow.Nodes[i].Status = status
+		}
+	}
+	s.saveWorkflow(workflow)
+}
+
+func (s *Service) GetWorkflow(ctx context.Context, id int32) (Workflow, error) {
+	s.wfMu.Lock()
+	defer s.wfMu.Unlock()
+	workflow, ok := s.workflows[id]
+	if !ok {
+		return Workflow{}, ErrWorkflowNotFound
+	}
+	return workflow.copy(), nil
+}
+
+// ListWorkflows returns the workflows ordered by id, only those of owner unless owner is empty
+func (s *Service) ListWorkflows(ctx context.Context, owner string) []Workflow {
+	s.wfMu.Lock()
+	defer s.wfMu.Unlock()
+	workflows := make([]Workflow, 0, len(s.workflows))
+	for _, workflow := range s.workflows {
+		if owner == "" || workflow.Owner == owner {
+			workflows = append(workflows, workflow.copy())
+		}
+	}
+	sort.Slice(workflows, func(i, j int) bool { return workflows[i].ID < workflows[j].ID })
+	return workflows
+}
+
+// StopWorkflow cancels the nodes that have not started and stops the running jobs of the workflow with SIGTERM,
+// killing them once grace has passed. It returns once every job has ended, stopping a workflow that has ended
+// does nothing.
+func (s *Service) StopWorkflow(ctx context.Context, id int32, grace time.Duration) (Workflow, error) {
+	s.wfMu.Lock()
+	workflow, ok := s.workflows[id]
+	if !ok {
+		s.wfMu.Unlock()
+		return Workflow{}, ErrWorkflowNotFound
+	}
+	var running []WorkflowNode
+	if workflow.Status == WorkflowRunning {
+		workflow.Status = WorkflowCancelled
+		workflow.EndedAt = time.Now()
+		for i, node := range workflow.Nodes {
+			switch {
+			case node.Status == NodePending:
+				workflow.Nodes[i].Status = NodeCancelled
+			case node.Status == NodeRunning && node.JobID != 0:
+				running = append(running, node)
+			}
+		}
+		s.workflows[id] = workflow
+		s.saveWorkflow(workflow)
+	}
+	s.wfMu.Unlock()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, len(running))
+	for _, node := range running {
+		wg.Add(1)
+		go func(node WorkflowNode) {
+			defer wg.Done()
+			record, err := s.StopJob(ctx, node.JobID, syscall.SIGTERM, grace)
+			if errors.Is(err, ErrJobNotFound) {
+				s.nodeEnded(id, node.Name, NodeCancelled)
+				return
+			}
+			if err != nil {
+				errs <- fmt.Errorf("stop job %d: %w", node.JobID, err)
+				return
+			}
+			// recorded right away rather than once the node's watcher notices, so the result is up to date
+			s.nodeEnded(id, node.Name, nodeStatus(record.Job.State()))
+		}(node)
+	}
+	wg.Wait()
+	close(errs)
+	if err := <-errs; err != nil {
+		return Workflow{}, err
+	}
+	return s.GetWorkflow(ctx, id)
+}
@@ -0,0 +1,211 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"job_runner/pkg/jobs"
+)
+
+func testNode(name string, condition NodeCondition, dependsOn ...string) WorkflowNode {
+	return WorkflowNode{Name: name, Condition: condition, DependsOn: dependsOn}
+}
+
+func Test_Workflow_Validate(t *testing.T) {
+	tests := []struct {
+		name  string
+		nodes []WorkflowNode
+		err   string
+	}{
+		{name: "chain", nodes: []WorkflowNode{testNode("a", ""), testNode("b", "", "a"), testNode("c", ConditionAlways, "a", "b")}},
+		{name: "diamond", nodes: []WorkflowNode{testNode("a", ""), testNode("b", "", "a"), testNode("c", "", "a"), testNode("d", "", "b", "c")}},
+		{name: "no nodes", err: "at least one node"},
+		{name: "no name", nodes: []WorkflowNode{testNode("", "")}, err: "has no name"},
+		{name: "duplicate name", nodes: []WorkflowNode{testNode("a", ""), testNode("a", "")}, err: `"a" is used twice`},
+		{name: "unknown condition", nodes: []WorkflowNode{testNode("a", "sometimes")}, err: "unknown condition"},
+		{name: "unknown dependency", nodes: []WorkflowNode{testNode("a", "", "b")}, err: `unknown node "b"`},
+		{name: "depends on itself", nodes: []WorkflowNode{testNode("a", "", "a")}, err: "depends on itself"},
+		{name: "cycle", nodes: []WorkflowNode{testNode("a", "", "b"), testNode("b", "", "a")}, err: "cycle"},
+		{name: "cycle after a valid node", nodes: []WorkflowNode{testNode("a", ""), testNode("b", "", "a", "d"), testNode("c", "", "b"), testNode("d", "", "c")}, err: "cycle"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workflow := Workflow{Nodes: tt.nodes}
+			err := workflow.validate()
+			if tt.err != "" {
+				require.ErrorIs(t, err, ErrInvalidWorkflow)
+				require.Contains(t, err.Error(), tt.err)
+				return
+			}
+			require.NoError(t, err)
+			// nodes without a condition run on success
+			for _, node := range workflow.Nodes {
+				require.NotEmpty(t, node.Condition)
+			}
+		})
+	}
+}
+
+func Test_Workflow_Decide(t *testing.T) {
+	tests := []struct {
+		name      string
+		deps      []NodeStatus
+		condition NodeCondition
+		status    NodeStatus
+		decided   bool
+	}{
+		{name: "no dependencies", condition: ConditionOnSuccess, status: NodeRunning, decided: true},
+		{name: "dependency running", deps: []NodeStatus{NodeSucceeded, NodeRunning}, condition: ConditionAlways, status: NodePending},
+		{name: "dependency pending", deps: []NodeStatus{NodePending}, condition: ConditionOnFailure, status: NodePending},
+		{name: "on success after success", deps: []NodeStatus{NodeSucceeded, NodeSucceeded}, condition: ConditionOnSuccess, status: NodeRunning, decided: true},
+		{name: "on success after failure", deps: []NodeStatus{NodeSucceeded, NodeFailed}, condition: ConditionOnSuccess, status: NodeSkipped, decided: true},
+		{name: "on success after skip", deps: []NodeStatus{NodeSkipped}, condition: ConditionOnSuccess, status: NodeSkipped, decided: true},
+		{name: "on failure after failure", deps: []NodeStatus{NodeSucceeded, NodeFailed}, condition: ConditionOnFailure, status: NodeRunning, decided: true},
+		{name: "on failure after success", deps: []NodeStatus{NodeSucceeded}, condition: ConditionOnFailure, status: NodeSkipped, decided: true},
+		// a skipped or cancelled dependency did not fail
+		{name: "on failure after skip", deps: []NodeStatus{NodeSkipped}, condition: ConditionOnFailure, status: NodeSkipped, decided: true},
+		{name: "on failure after cancel", deps: []NodeStatus{NodeCancelled}, condition: ConditionOnFailure, status: NodeSkipped, decided: true},
+		{name: "always after skip", deps: []NodeStatus{NodeSkipped, NodeFailed}, condition: ConditionAlways, status: NodeRunning, decided: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var workflow Workflow
+			target := WorkflowNode{Name: "target", Condition: tt.condition, Status: NodePending}
+			for i, status := range tt.deps {
+				name := string(rune('a' + i))
+				workflow.Nodes = append(workflow.Nodes, WorkflowNode{Name: name, Status: status})
+				target.DependsOn = append(target.DependsOn, name)
+			}
+			workflow.Nodes = append(workflow.Nodes, target)
+
+			status, decided := workflow.decide(target)
+			require.Equal(t, tt.status, status)
+			require.Equal(t, tt.decided, decided)
+		})
+	}
+}
+
+func commandNode(name string, condition NodeCondition, command []string, dependsOn ...string) WorkflowNode {
+	n := testNode(name, condition, dependsOn...)
+	n.Spec = jobs.Spec{Command: command, Limits: testLimits}
+	return n
+}
+
+// waitWorkflow waits for the workflow to end and returns it
+func waitWorkflow(t *testing.T, s *Service, id int32) Workflow {
+	var workflow Workflow
+	require.Eventually(t, func() bool {
+		var err error
+		workflow, err = s.GetWorkflow(context.Background(), id)
+		return err == nil && workflow.Status != WorkflowRunning
+	}, 5*time.Second, 10*time.Millisecond, "workflow %d did not end", id)
+	return workflow
+}
+
+func nodeStatuses(workflow Workflow) map[string]NodeStatus {
+	statuses := make(map[string]NodeStatus, len(workflow.Nodes))
+	for _, node := range workflow.Nodes {
+		statuses[node.Name] = node.Status
+	}
+	return statuses
+}
+
+func Test_Service_WorkflowAdvance(t *testing.T) {
+	tests := []struct {
+		name     string
+		nodes    []WorkflowNode
+		statuses map[string]NodeStatus
+		status   WorkflowStatus
+	}{
+		{
+			name: "success",
+			nodes: []WorkflowNode{
+				commandNode("build", "", []string{"true"}),
+				commandNode("test", "", []string{"true"}, "build"),
+				commandNode("notify", ConditionOnFailure, []string{"true"}, "test"),
+			},
+			statuses: map[string]NodeStatus{"build": NodeSucceeded, "test": NodeSucceeded, "notify": NodeSkipped},
+			status:   WorkflowSucceeded,
+		},
+		{
+			name: "skips propagate and failures are handled",
+			nodes: []WorkflowNode{
+				commandNode("build", "", []string{"false"}),
+				commandNode("test", "", []string{"true"}, "build"),
+				commandNode("deploy", "", []string{"true"}, "test"),
+				commandNode("rollback", ConditionOnFailure, []string{"true"}, "build"),
+				// a skipped dependency has not failed
+				commandNode("alert", ConditionOnFailure, []string{"true"}, "test"),
+				commandNode("cleanup", ConditionAlways, []string{"true"}, "deploy", "rollback"),
+			},
+			statuses: map[string]NodeStatus{
+				"build": NodeFailed, "test": NodeSkipped, "deploy": NodeSkipped,
+				"rollback": NodeSucceeded, "alert": NodeSkipped, "cleanup": NodeSucceeded,
+			},
+			status: WorkflowFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService(t, ServiceConfig{})
+			started, err := s.StartWorkflow(context.Background(), Workflow{Owner: "alice", Nodes: tt.nodes})
+			require.NoError(t, err)
+
+			workflow := waitWorkflow(t, s, started.ID)
+			require.Equal(t, tt.status, workflow.Status)
+			require.Equal(t, tt.statuses, nodeStatuses(workflow))
+			require.False(t, workflow.EndedAt.IsZero())
+			for _, node := range workflow.Nodes {
+				// only the nodes that ran have a job
+				require.Equal(t, node.Status == NodeSkipped, node.JobID == 0, node.Name)
+			}
+		})
+	}
+}
+
+func Test_Service_RestoreWorkflows(t *testing.T) {
+	store := endedStore(t, 1)
+	running := Workflow{
+		ID:     3,
+		Owner:  "alice",
+		Status: WorkflowRunning,
+		Nodes: []WorkflowNode{
+			// the job of the node ended while the server was down
+			{Name: "done", Status: NodeRunning, JobID: 1, Condition: ConditionOnSuccess},
+			// the job of the node is gone
+			{Name: "gone", Status: NodeRunning, JobID: 7, Condition: ConditionOnSuccess},
+			commandNode("next", ConditionOnSuccess, []string{"true"}, "done"),
+			commandNode("recover", ConditionOnFailure, []string{"true"}, "gone"),
+			commandNode("after", ConditionOnSuccess, []string{"true"}, "gone"),
+		},
+	}
+	for i := range running.Nodes {
+		if running.Nodes[i].Status == "" {
+			running.Nodes[i].Status = NodePending
+		}
+	}
+	ended := Workflow{ID: 2, Owner: "alice", Status: WorkflowSucceeded, Nodes: []WorkflowNode{{Name: "a", Status: NodeSucceeded, JobID: 1}}}
+	require.NoError(t, store.SaveWorkflow(running))
+	require.NoError(t, store.SaveWorkflow(ended))
+
+	s := newTestService(t, ServiceConfig{Store: store})
+	workflow := waitWorkflow(t, s, running.ID)
+	require.Equal(t, WorkflowFailed, workflow.Status)
+	require.Equal(t, map[string]NodeStatus{
+		"done": NodeSucceeded, "gone": NodeFailed, "next": NodeSucceeded, "recover": NodeSucceeded, "after": NodeSkipped,
+	}, nodeStatuses(workflow))
+
+	// ended workflows are kept as they were
+	restored, err := s.GetWorkflow(context.Background(), ended.ID)
+	require.NoError(t, err)
+	require.Equal(t, ended.Status, restored.Status)
+
+	// new workflows are numbered after the stored ones
+	started, err := s.StartWorkflow(context.Background(), Workflow{Owner: "alice", Nodes: []WorkflowNode{commandNode("a", "", []string{"true"})}})
+	require.NoError(t, err)
+	require.Equal(t, int32(4), started.ID)
+	waitWorkflow(t, s, started.ID)
+}
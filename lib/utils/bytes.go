@@ -0,0 +1,60 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var byteUnits = map[string]int64{
+	"":  1,
+	"B": 1,
+	"K": 1 << 10,
+	"M": 1 << 20,
+	"G": 1 << 30,
+	"T": 1 << 40,
+}
+
+// ParseBytes parses a size such as "512M" or "1.5G" into bytes. Units are powers of 1024 and may be followed
+// by an optional "B" or "iB", so "512M", "512MB" and "512MiB" are the same size. A plain number is bytes.
+func ParseBytes(s string) (int64, error) {
+	str := strings.ToUpper(strings.TrimSpace(s))
+	str = strings.TrimSuffix(str, "IB")
+	if len(str) > 1 {
+		str = strings.TrimSuffix(str, "B")
+	}
+	i := strings.IndexFunc(str, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	num, unit := str, ""
+	if i >= 0 {
+		num, unit = str[:i], str[i:]
+	}
+	multiplier, ok := byteUnits[unit]
+	if !ok {
+		return 0, fmt.Errorf("invalid size %q: unknown unit %q", s, unit)
+	}
+	value, err := strconv.ParseFloat(num, 64)
+	if err != nil || value < 0 {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+	return int64(value * float64(multiplier)), nil
+}
+
+// FormatBytes formats bytes in the largest unit of ParseBytes it reaches, such as "1.5G", with one decimal
+func FormatBytes(n uint64) string {
+	units := []string{"K", "M", "G", "T"}
+	if n < 1<<10 {
+		return strconv.FormatUint(n, 10) + "B"
+	}
+	value := float64(n)
+	unit := ""
+	for _, u := range units {
+		if value < 1<<10 {
+			break
+		}
+		value /= 1 << 10
+		unit = u
+	}
+	return strconv.FormatFloat(value, 'f', 1, 64) + unit
+}
@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseBytes(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{input: "100", expected: 100},
+		{input: "100B", expected: 100},
+		{input: "1K", expected: 1024},
+		{input: "512M", expected: 512 << 20},
+		{input: "512mb", expected: 512 << 20},
+		{input: "512MiB", expected: 512 << 20},
+		{input: "1.5G", expected: 3 << 29},
+		{input: "2T", expected: 2 << 40},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			got, err := ParseBytes(test.input)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, got)
+		})
+	}
+
+	for _, input := range []string{"", "M", "12X", "-1M", "1..2K"} {
+		_, err := ParseBytes(input)
+		require.Error(t, err, input)
+	}
+}
+
+func Test_FormatBytes(t *testing.T) {
+	tests := []struct {
+		input    uint64
+		expected string
+	}{
+		{input: 0, expected: "0B"},
+		{input: 1023, expected: "1023B"},
+		{input: 1024, expected: "1.0K"},
+		{input: 3 << 29, expected: "1.5G"},
+		{input: 2 << 40, expected: "2.0T"},
+		{input: 2048 << 40, expected: "2048.0T"},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.expected, FormatBytes(test.input), test.input)
+	}
+}
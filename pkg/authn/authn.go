@@ -2,7 +2,6 @@ package authn
 
 import (
 	"context"
-	"encoding/base64"
 	"fmt"
 
 	"google.golang.org/grpc"
@@ -32,7 +31,7 @@ func FromMD(ctx context.Context) (UserID, error) {
 }
 
 func UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-	ctx, err := putHashedCertOnCtx(ctx)
+	ctx, err := putSubjectOnCtx(ctx)
 	if err != nil {
 		return nil, status.Error(codes.Unauthenticated, "failed to get id")
 	}
@@ -49,14 +48,16 @@ func (w *wrapper) Context() context.Context {
 }
 
 func StreamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
-	ctx, err := putHashedCertOnCtx(ss.Context())
+	ctx, err := putSubjectOnCtx(ss.Context())
 	if err != nil {
 		return status.Error(codes.Unauthenticated, "failed to get id")
 	}
 	return handler(srv, &wrapper{ss, ctx})
 }
 
-func putHashedCertOnCtx(ctx context.Context) (context.Context, error) {
+// putSubjectOnCtx puts the common name of the peer certificate's subject on the incoming metadata.
+// The common name is the subject known to the authorizer and recorded as the owner of jobs.
+func putSubjectOnCtx(ctx context.Context) (context.Context, error) {
 	peer, ok := peer.FromContext(ctx)
 	if !ok {
 		return nil, status.Error(codes.Unauthenticated, "missing cert")
@@ -69,12 +70,15 @@ func putHashedCertOnCtx(ctx context.Context) (context.Context, error) {
 		return nil, status.Error(codes.Unauthenticated, "missing cert")
 	}
 	cert := peerInfo.State.PeerCertificates[0]
-	str := base64.StdEncoding.EncodeToString(cert.RawSubject)
+	str := cert.Subject.CommonName
+	if str == "" {
+		return nil, status.Error(codes.Unauthenticated, "missing subject")
+	}
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
 		md = metadata.New(make(map[string]string))
 	}
-	md.Append("authn_id", str)
+	md.Set(authnKeyString, str)
 	ctx = metadata.NewIncomingContext(ctx, md)
 	return ctx, nil
 }
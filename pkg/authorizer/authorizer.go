@@ -1,17 +1,53 @@
 package authorizer
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 const (
 	ActionStart  = "start"
 	ActionGet    = "get"
 	ActionStop   = "stop"
 	ActionStream = "stream"
+	ActionList   = "list"
+	ActionAttach = "attach"
+	ActionWatch  = "watch"
+	ActionSignal = "signal"
+	ActionDelete = "delete"
+	// ActionPriority changes the priority of a job after it was started
+	ActionPriority = "priority"
+	// ActionSchedule manages the schedules that start jobs on a recurring basis, creating one also requires start
+	ActionSchedule = "schedule"
+)
+
+// scopes of an action on jobs. A role allows an action on the jobs it started with action:own
+// and on every job with action:any. An action without a scope is treated as action:any.
+const (
+	ScopeOwn = "own"
+	ScopeAny = "any"
 )
 
+// Own returns the permission to perform action on the subject's own jobs
+func Own(action string) string {
+	return action + ":" + ScopeOwn
+}
+
+// Any returns the permission to perform action on every job
+func Any(action string) string {
+	return action + ":" + ScopeAny
+}
+
 type Role struct {
 	Name    string
 	Actions []string
+	// priorities the role can give jobs, a role that leaves both at zero can only use priority 0
+	MinPriority int32
+	MaxPriority int32
+	// users and groups, by numeric id, the role can run jobs as when a job asks for one. Jobs that do not ask
+	// run as the default user of the server.
+	UIDs []uint32
+	GIDs []uint32
 }
 
 type User struct {
@@ -23,15 +59,41 @@ type Authorizer struct {
 	Users map[string]User
 }
 
+// nobody is the id of the unprivileged user and group every role that starts jobs can run them as
+const nobody = 65534
+
 func NewAuthorizer() *Authorizer {
 	adminRole := Role{
-		Name:    "admin",
-		Actions: []string{ActionGet, ActionStart, ActionStop, ActionStream},
+		Name: "admin",
+		Actions: []string{
+			ActionStart,
+			Any(ActionGet), Any(ActionStop), Any(ActionStream), Any(ActionList),
+			Any(ActionAttach), Any(ActionWatch), Any(ActionSignal), Any(ActionDelete),
+			Any(ActionPriority), Any(ActionSchedule),
+		},
+		MinPriority: -100,
+		MaxPriority: 100,
+		UIDs:        []uint32{0, nobody},
+		GIDs:        []uint32{0, nobody},
+	}
+
+	userRole := Role{
+		Name: "user",
+		Actions: []string{
+			ActionStart,
+			Own(ActionGet), Own(ActionStop), Own(ActionStream), Own(ActionList),
+			Own(ActionAttach), Own(ActionWatch), Own(ActionSignal), Own(ActionDelete),
+			Own(ActionPriority), Own(ActionSchedule),
+		},
+		MinPriority: -100,
+		MaxPriority: 10,
+		UIDs:        []uint32{nobody},
+		GIDs:        []uint32{nobody},
 	}
 
 	viewerRole := Role{
 		Name:    "viewer",
-		Actions: []string{ActionGet, ActionStream},
+		Actions: []string{Any(ActionGet), Any(ActionList), Any(ActionWatch)},
 	}
 
 	alice := User{
@@ -39,6 +101,11 @@ func NewAuthorizer() *Authorizer {
 		Roles:   []Role{adminRole},
 	}
 
+	bob := User{
+		Subject: "bob",
+		Roles:   []Role{userRole},
+	}
+
 	victor := User{
 		Subject: "victor",
 		Roles:   []Role{viewerRole},
@@ -46,12 +113,13 @@ func NewAuthorizer() *Authorizer {
 
 	fixtures := map[string]User{
 		alice.Subject:  alice,
+		bob.Subject:    bob,
 		victor.Subject: victor,
 	}
 	return &Authorizer{Users: fixtures}
 }
 
-// HasAccess determines if the subject has access to perform action.
+// HasAccess determines if the subject has access to perform action on at least some jobs, in any scope.
 func (a *Authorizer) HasAccess(subject string, action string) (bool, error) {
 	user, ok := a.Users[subject]
 	if !ok {
@@ -60,7 +128,27 @@ func (a *Authorizer) HasAccess(subject string, action string) (bool, error) {
 	// the double for loop heres can be optimized using maps
 	for _, role := range user.Roles {
 		for _, allowedActions := range role.Actions {
-			if action == allowedActions {
+			if allowedAction, _ := splitScope(allowedActions); action == allowedAction {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// Authorize determines if the subject has access to perform action on a job started by owner.
+func (a *Authorizer) Authorize(subject string, action string, owner string) (bool, error) {
+	user, ok := a.Users[subject]
+	if !ok {
+		return false, fmt.Errorf("subject %s not found", subject)
+	}
+	for _, role := range user.Roles {
+		for _, allowedActions := range role.Actions {
+			allowedAction, scope := splitScope(allowedActions)
+			if action != allowedAction {
+				continue
+			}
+			if scope == ScopeAny || (scope == ScopeOwn && owner == subject) {
 				return true, nil
 			}
 		}
@@ -68,5 +156,55 @@ func (a *Authorizer) HasAccess(subject string, action string) (bool, error) {
 	return false, nil
 }
 
+// PriorityRange returns the lowest and highest priority the subject can give jobs across its roles
+func (a *Authorizer) PriorityRange(subject string) (int32, int32, error) {
+	user, ok := a.Users[subject]
+	if !ok {
+		return 0, 0, fmt.Errorf("subject %s not found", subject)
+	}
+	var min, max int32
+	for _, role := range user.Roles {
+		if role.MinPriority < min {
+			min = role.MinPriority
+		}
+		if role.MaxPriority > max {
+			max = role.MaxPriority
+		}
+	}
+	return min, max, nil
+}
+
+// AllowCredential determines if the subject can run jobs as user uid and group gid, each allowed by any of its roles
+func (a *Authorizer) AllowCredential(subject string, uid, gid uint32) (bool, error) {
+	user, ok := a.Users[subject]
+	if !ok {
+		return false, fmt.Errorf("subject %s not found", subject)
+	}
+	var uidOK, gidOK bool
+	for _, role := range user.Roles {
+		uidOK = uidOK || containsID(role.UIDs, uid)
+		gidOK = gidOK || containsID(role.GIDs, gid)
+	}
+	return uidOK && gidOK, nil
+}
+
+func containsID(ids []uint32, id uint32) bool {
+	for _, allowed := range ids {
+		if allowed == id {
+			return true
+		}
+	}
+	return false
+}
+
+// splitScope splits a permission into its action and scope
+func splitScope(permission string) (string, string) {
+	action, scope, ok := strings.Cut(permission, ":")
+	if !ok {
+		return action, ScopeAny
+	}
+	return action, scope
+}
+
 // authorizer should have methods to create users, roles, etc but we omit them here.
 // preload authorizer with some fixture data that matches the subject in the fixture certs
@@ -0,0 +1,104 @@
+package authorizer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Authorize_Scopes(t *testing.T) {
+	authz := NewAuthorizer()
+
+	tests := []struct {
+		name    string
+		subject string
+		action  string
+		owner   string
+		allowed bool
+	}{
+		{name: "admin stops any job", subject: "alice", action: ActionStop, owner: "bob", allowed: true},
+		{name: "user stops own job", subject: "bob", action: ActionStop, owner: "bob", allowed: true},
+		{name: "user can not stop others jobs", subject: "bob", action: ActionStop, owner: "alice", allowed: false},
+		{name: "viewer gets any job", subject: "victor", action: ActionGet, owner: "alice", allowed: true},
+		{name: "viewer can not stream", subject: "victor", action: ActionStream, owner: "victor", allowed: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			allowed, err := authz.Authorize(test.subject, test.action, test.owner)
+			require.NoError(t, err)
+			require.Equal(t, test.allowed, allowed)
+		})
+	}
+}
+
+func Test_HasAccess_AnyScope(t *testing.T) {
+	authz := NewAuthorizer()
+
+	ok, err := authz.HasAccess("bob", ActionStop)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = authz.HasAccess("victor", ActionStop)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	_, err = authz.HasAccess("mallory", ActionGet)
+	require.Error(t, err)
+}
+
+func Test_Authorize_UnscopedActionMeansAny(t *testing.T) {
+	authz := &Authorizer{Users: map[string]User{
+		"carol": {Subject: "carol", Roles: []Role{{Name: "legacy", Actions: []string{ActionGet}}}},
+	}}
+
+	ok, err := authz.Authorize("carol", ActionGet, "alice")
+	require.NoError(t, err)
+	require.True(t, ok)
+}
+
+func Test_PriorityRange(t *testing.T) {
+	authz := NewAuthorizer()
+
+	min, max, err := authz.PriorityRange("bob")
+	require.NoError(t, err)
+	require.Equal(t, int32(-100), min)
+	require.Equal(t, int32(10), max)
+
+	min, max, err = authz.PriorityRange("victor")
+	require.NoError(t, err)
+	require.Equal(t, int32(0), min)
+	require.Equal(t, int32(0), max)
+
+	_, _, err = authz.PriorityRange("mallory")
+	require.Error(t, err)
+}
+
+func Test_AllowCredential(t *testing.T) {
+	authz := NewAuthorizer()
+
+	tests := []struct {
+		name    string
+		subject string
+		uid     uint32
+		gid     uint32
+		allowed bool
+	}{
+		{name: "admin runs as root", subject: "alice", uid: 0, gid: 0, allowed: true},
+		{name: "admin runs as nobody", subject: "alice", uid: nobody, gid: nobody, allowed: true},
+		{name: "user runs as nobody", subject: "bob", uid: nobody, gid: nobody, allowed: true},
+		{name: "user can not run as root", subject: "bob", uid: 0, gid: 0},
+		{name: "user can not use the root group", subject: "bob", uid: nobody, gid: 0},
+		{name: "unlisted user", subject: "alice", uid: 1000, gid: nobody},
+		{name: "viewer", subject: "victor", uid: nobody, gid: nobody},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			allowed, err := authz.AllowCredential(test.subject, test.uid, test.gid)
+			require.NoError(t, err)
+			require.Equal(t, test.allowed, allowed)
+		})
+	}
+
+	_, err := authz.AllowCredential("mallory", nobody, nobody)
+	require.Error(t, err)
+}
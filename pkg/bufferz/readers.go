@@ -1,9 +1,12 @@
 package bufferz
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"io"
+	"os"
 	"sync"
 	"sync/atomic"
 )
@@ -14,11 +17,21 @@ import (
 // when it reaches the end
 //
 // When Close is called, the Write call will return an error.
+//
+// SetLimit caps the amount of data kept. Readers that fall behind data dropped by LimitRing skip ahead to the
+// oldest data kept, which Reader.Skipped reports.
 type MultiReader struct {
 	mu   sync.Mutex
 	cond *sync.Cond
 
-	data []byte
+	// guarded by mu
+	data      storage
+	limit     int64
+	policy    LimitPolicy
+	truncated int64
+	// closed when the limit is first reached
+	exceeded     chan struct{}
+	exceededOnce sync.Once
 
 	// signals the writers to return an io.EOF
 	// signals to current and future readers to return io.EOF when each reader has finished reading data
@@ -26,32 +39,209 @@ type MultiReader struct {
 	closed    uint32 // closeCalled if non-zero
 }
 
+// NewMultiReaderBuffer returns a MultiReader keeping all data in memory
 func NewMultiReaderBuffer() *MultiReader {
+	return newMultiReader(&memoryStorage{})
+}
+
+func newMultiReader(data storage) *MultiReader {
 	ret := &MultiReader{
 		mu:        sync.Mutex{},
+		data:      data,
 		closeChan: make(chan struct{}),
+		exceeded:  make(chan struct{}),
 	}
 	ret.cond = sync.NewCond(&ret.mu)
 	return ret
 }
 
+// errDropped is returned when data is dropped by LimitRing while it is read
+var errDropped = errors.New("data was dropped")
+
+// LimitPolicy selects what a MultiReader does with writes once its limit is reached
+type LimitPolicy int
+
+const (
+	// LimitRing keeps the most recent data, dropping the oldest data to make room for writes
+	LimitRing LimitPolicy = iota
+	// LimitStop keeps the data up to the limit and discards the data of later writes
+	LimitStop
+)
+
+// SetLimit caps the data kept to limit bytes, a limit of 0 keeps all data. It must be called before the first Write.
+// Writes past the limit succeed, what happens to their data is chosen by policy.
+func (m *MultiReader) SetLimit(limit int64, policy LimitPolicy) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.limit = limit
+	m.policy = policy
+}
+
 // Write must be used by a single writer. It is invalid to call Write after Close.
 func (m *MultiReader) Write(p []byte) (int, error) {
 	if m.closeCalled() {
 		return 0, errors.New("multireader: close called")
 	}
 	m.mu.Lock()
-	m.data = append(m.data, p...)
+	err := m.write(p)
 	m.mu.Unlock()
+	if err != nil {
+		return 0, fmt.Errorf("multireader: %w", err)
+	}
 	m.cond.Broadcast()
 	return len(p), nil
 }
 
+func (m *MultiReader) write(p []byte) error {
+	if m.limit <= 0 {
+		return m.data.write(p)
+	}
+	switch m.policy {
+	case LimitStop:
+		if room := m.limit - m.data.size(); int64(len(p)) > room {
+			if room < 0 {
+				room = 0
+			}
+			m.truncated += int64(len(p)) - room
+			p = p[:room]
+			m.exceed()
+		}
+		if len(p) == 0 {
+			return nil
+		}
+		return m.data.write(p)
+	default:
+		if err := m.data.write(p); err != nil {
+			return err
+		}
+		if start := m.data.size() - m.limit; start > m.data.start() {
+			m.exceed()
+			if err := m.data.discard(start); err != nil {
+				return err
+			}
+			m.truncated = start
+		}
+		return nil
+	}
+}
+
+func (m *MultiReader) exceed() {
+	m.exceededOnce.Do(func() { close(m.exceeded) })
+}
+
+// Exceeded returns a channel that is closed once writes exceed the limit set by SetLimit
+func (m *MultiReader) Exceeded() <-chan struct{} {
+	return m.exceeded
+}
+
+// Truncated returns the number of bytes written that can not be read because the limit was exceeded,
+// either dropped by LimitRing or discarded by LimitStop.
+func (m *MultiReader) Truncated() int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.truncated
+}
+
 // GetReader returns independent io.Readers to the underlying data. Each individual reader returned by
 // calling GetReader() is not safe to use concurrently. For each goroutine, get a new Reader.
 // When the reader gets to the end of the data and Close is called, the reader will return io.EOF.
 func (m *MultiReader) GetReader(ctx context.Context) io.Reader {
-	var pos int
+	return m.GetReaderAt(ctx, 0, true)
+}
+
+// Len returns the offset following the last byte kept, which is the number of bytes written so far unless
+// LimitStop discarded some.
+func (m *MultiReader) Len() int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.data.size()
+}
+
+// Start returns the offset of the oldest byte kept, which is 0 unless LimitRing dropped data.
+func (m *MultiReader) Start() int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.data.start()
+}
+
+// LineOffset returns the offset of the first byte of the last n lines written so far. A trailing newline
+// does not start a new line. If there are fewer than n lines kept, the offset is Start.
+func (m *MultiReader) LineOffset(n int) int64 {
+	m.mu.Lock()
+	end := m.data.size()
+	first := m.data.start()
+	m.mu.Unlock()
+	if n <= 0 {
+		return end
+	}
+
+	// scan backwards from the end a chunk at a time, counting newlines
+	var r spanReader
+	defer r.close()
+	buf := make([]byte, 32*1024)
+	last := true
+	for end > first {
+		start := end - int64(len(buf))
+		if start < first {
+			start = first
+		}
+		chunk := buf[:end-start]
+		if err := m.readAt(&r, chunk, start); err != nil {
+			return first
+		}
+		if last && chunk[len(chunk)-1] == '\n' {
+			chunk = chunk[:len(chunk)-1]
+		}
+		last = false
+		for i := len(chunk); ; {
+			i = bytes.LastIndexByte(chunk[:i], '\n')
+			if i < 0 {
+				break
+			}
+			n--
+			if n == 0 {
+				return start + int64(i) + 1
+			}
+		}
+		end = start
+	}
+	return first
+}
+
+// readAt fills p with the data at off, holding the lock only to locate the data so reading it does not block
+// writes and other readers
+func (m *MultiReader) readAt(r *spanReader, p []byte, off int64) error {
+	for len(p) > 0 {
+		m.mu.Lock()
+		if off < m.data.start() {
+			m.mu.Unlock()
+			return errDropped
+		}
+		s := m.data.locate(off, len(p))
+		m.mu.Unlock()
+
+		n, err := r.read(p, s)
+		if err != nil {
+			return err
+		}
+		p = p[n:]
+		off += int64(n)
+	}
+	return nil
+}
+
+// GetReaderAt is like GetReader but the reader starts at offset instead of the beginning of the data.
+// If follow is false, the reader returns io.EOF once it reaches the amount of data written at the time
+// GetReaderAt was called instead of waiting for more writes.
+func (m *MultiReader) GetReaderAt(ctx context.Context, offset int64, follow bool) *Reader {
+	pos := offset
+	if pos < 0 {
+		pos = 0
+	}
+	end := int64(-1)
+	if !follow {
+		end = m.Len()
+	}
 
 	// we spin up a goroutine here to mainly listen for context cancellation.
 	// the goroutine is cleaned up when context cancellation occurs or when Close() is called.
@@ -67,17 +257,24 @@ func (m *MultiReader) GetReader(ctx context.Context) io.Reader {
 		}
 	}()
 
-	return reader(func(p []byte) (int, error) {
+	r := &Reader{}
+	// locate waits for data to read and locates up to n bytes of it, or returns io.EOF if there is nothing left.
+	// eof is set if no more data follows the located data.
+	locate := func(n int) (s span, eof bool, err error) {
 		m.mu.Lock()
 		defer m.mu.Unlock()
 
+		if end >= 0 && pos >= end {
+			return span{}, false, io.EOF
+		}
+
 	loop:
 		for {
 			// Readers exit when...
 			select {
 			case <-ctx.Done():
 				// 1) Their context is cancelled: we return the ctx.Err() here
-				return 0, ctx.Err()
+				return span{}, false, ctx.Err()
 			case <-m.closeChan:
 				// 2) Close is called.
 				// We check if there is new data to read even is close is called.
@@ -88,7 +285,7 @@ func (m *MultiReader) GetReader(ctx context.Context) io.Reader {
 			}
 
 			// If there is new data to read
-			if pos < len(m.data) {
+			if pos < m.data.size() {
 				break loop
 			}
 
@@ -102,19 +299,55 @@ func (m *MultiReader) GetReader(ctx context.Context) io.Reader {
 			m.cond.Wait()
 		}
 
-		var n int
-		// only call copy if there is new data to read
-		if pos < len(m.data) {
-			n = copy(p, m.data[pos:])
-			pos += n
+		// data dropped by LimitRing before the reader got to it is skipped
+		if start := m.data.start(); pos < start {
+			r.skipped += start - pos
+			pos = start
+		}
+
+		limit := m.data.size()
+		if end >= 0 && end < limit {
+			limit = end
+		}
+		// only read if there is new data to read
+		if pos < limit {
+			if int64(n) > limit-pos {
+				n = int(limit - pos)
+			}
+			s = m.data.locate(pos, n)
 		}
 
 		// if we reached the end of the stream and no more writes will occur, we reached EOF
-		if m.closeCalled() && pos == len(m.data) {
-			return n, io.EOF
+		next := pos + int64(s.size)
+		return s, (m.closeCalled() && next >= m.data.size()) || (end >= 0 && next >= end), nil
+	}
+
+	r.read = func(p []byte) (int, error) {
+		for {
+			s, eof, err := locate(len(p))
+			if err != nil {
+				r.file.close()
+				return 0, err
+			}
+			// the data is read without holding the lock
+			n, err := r.file.read(p, s)
+			pos += int64(n)
+			if errors.Is(err, os.ErrNotExist) && pos < m.Start() {
+				// the segment was removed after it was located because LimitRing dropped its data, which is skipped
+				continue
+			}
+			if err != nil {
+				r.file.close()
+				return n, fmt.Errorf("multireader: %w", err)
+			}
+			if eof {
+				r.file.close()
+				return n, io.EOF
+			}
+			return n, nil
 		}
-		return n, nil
-	})
+	}
+	return r
 }
 
 func (m *MultiReader) closeCalled() bool {
@@ -134,9 +367,74 @@ func (m *MultiReader) Close() error {
 	defer m.mu.Unlock()
 	close(m.closeChan)
 	m.cond.Broadcast()
+	// data stays readable after close
+	return m.data.closeWriter()
+}
+
+// storage holds the data written to a MultiReader, which serializes access to it
+type storage interface {
+	write(p []byte) error
+	// size is the offset following the last byte written
+	size() int64
+	// start is the offset of the oldest byte kept
+	start() int64
+	// discard drops the data before off, storage may keep some of it until it can be released
+	discard(off int64) error
+	// locate returns where up to n bytes at off are read from once the lock of the MultiReader is released,
+	// the caller ensures they have been written
+	locate(off int64, n int) span
+	// closeWriter releases resources held for writing
+	closeWriter() error
+}
+
+// memoryStorage keeps all data in memory
+type memoryStorage struct {
+	// data holds the data from offset base
+	data []byte
+	base int64
+}
+
+func (m *memoryStorage) write(p []byte) error {
+	m.data = append(m.data, p...)
+	return nil
+}
+
+func (m *memoryStorage) size() int64 {
+	return m.base + int64(len(m.data))
+}
+
+func (m *memoryStorage) start() int64 {
+	return m.base
+}
+
+// discard reslices the data, the dropped bytes are released once append moves the data to a new array
+func (m *memoryStorage) discard(off int64) error {
+	m.data = m.data[off-m.base:]
+	m.base = off
 	return nil
 }
 
-type reader func([]byte) (int, error)
+// locate returns the data itself, written bytes are never modified so they can be read without the lock
+func (m *memoryStorage) locate(off int64, n int) span {
+	data := m.data[off-m.base:][:n]
+	return span{data: data, size: n}
+}
 
-func (r reader) Read(p []byte) (int, error) { return r(p) }
+func (m *memoryStorage) closeWriter() error {
+	return nil
+}
+
+// Reader reads the data of a MultiReader from an offset. It is not safe for concurrent use.
+type Reader struct {
+	read    func([]byte) (int, error)
+	skipped int64
+	// file keeps the segment file being read open between reads until the reader is done
+	file spanReader
+}
+
+func (r *Reader) Read(p []byte) (int, error) { return r.read(p) }
+
+// Skipped returns the number of bytes the reader skipped because LimitRing dropped them before they were read
+func (r *Reader) Skipped() int64 {
+	return r.skipped
+}
@@ -18,7 +18,8 @@ func Test_MultiReader_BasicBehavior(t *testing.T) {
 	require.Equal(t, len(input), n)
 
 	reader := multireader.GetReader(context.Background())
-	got, err := io.ReadAll(reader)
+	got := make([]byte, len(input))
+	_, err = io.ReadFull(reader, got)
 	require.NoError(t, err)
 	require.Equal(t, input, got)
 	err = multireader.Close()
@@ -151,3 +152,98 @@ func Test_MultiReader_CancelOneReaderOfManyWhileWaitingForMoreData(t *testing.T)
 	require.NoError(t, err)
 	wg.Wait()
 }
+
+func Test_MultiReader_GetReaderAtOffset(t *testing.T) {
+	multireader := NewMultiReaderBuffer()
+	_, _ = multireader.Write([]byte("foo.bar.baz"))
+	require.NoError(t, multireader.Close())
+
+	got, err := io.ReadAll(multireader.GetReaderAt(context.Background(), 4, true))
+	require.NoError(t, err)
+	require.Equal(t, "bar.baz", string(got))
+
+	// an offset past the end of a closed buffer has nothing to read
+	got, err = io.ReadAll(multireader.GetReaderAt(context.Background(), 100, true))
+	require.NoError(t, err)
+	require.Empty(t, got)
+}
+
+func Test_MultiReader_NoFollowStopsAtCurrentEnd(t *testing.T) {
+	multireader := NewMultiReaderBuffer()
+	defer multireader.Close()
+	_, _ = multireader.Write([]byte("foo."))
+
+	reader := multireader.GetReaderAt(context.Background(), 0, false)
+	_, _ = multireader.Write([]byte("bar"))
+
+	// the reader must not block waiting for writes even though Close was not called
+	got, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	require.Equal(t, "foo.", string(got))
+}
+
+func Test_MultiReader_LineOffset(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		lines    int
+		expected int64
+	}{
+		{name: "empty", input: "", lines: 3, expected: 0},
+		{name: "trailing newline", input: "a\nbb\nccc\n", lines: 2, expected: 2},
+		{name: "no trailing newline", input: "a\nbb\nccc", lines: 1, expected: 5},
+		{name: "fewer lines than requested", input: "a\nbb\n", lines: 5, expected: 0},
+		{name: "zero lines", input: "a\nbb\n", lines: 0, expected: 5},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			multireader := NewMultiReaderBuffer()
+			_, _ = multireader.Write([]byte(test.input))
+			require.Equal(t, test.expected, multireader.LineOffset(test.lines))
+		})
+	}
+}
+
+func Test_MultiReader_LimitRing(t *testing.T) {
+	multireader := NewMultiReaderBuffer()
+	multireader.SetLimit(8, LimitRing)
+
+	_, err := multireader.Write([]byte("0123456789"))
+	require.NoError(t, err)
+	_, err = multireader.Write([]byte("abc"))
+	require.NoError(t, err)
+	require.NoError(t, multireader.Close())
+
+	require.Equal(t, int64(13), multireader.Len())
+	require.Equal(t, int64(5), multireader.Start())
+	require.Equal(t, int64(5), multireader.Truncated())
+	select {
+	case <-multireader.Exceeded():
+	default:
+		t.Fatal("limit not reported as exceeded")
+	}
+
+	reader := multireader.GetReaderAt(context.Background(), 2, true)
+	got, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	require.Equal(t, "56789abc", string(got))
+	require.Equal(t, int64(3), reader.Skipped())
+}
+
+func Test_MultiReader_LimitStop(t *testing.T) {
+	multireader := NewMultiReaderBuffer()
+	multireader.SetLimit(4, LimitStop)
+
+	for _, chunk := range []string{"ab", "cdef", "gh"} {
+		n, err := multireader.Write([]byte(chunk))
+		require.NoError(t, err)
+		require.Equal(t, len(chunk), n)
+	}
+	require.NoError(t, multireader.Close())
+
+	require.Equal(t, int64(4), multireader.Len())
+	require.Equal(t, int64(4), multireader.Truncated())
+	got, err := io.ReadAll(multireader.GetReader(context.Background()))
+	require.NoError(t, err)
+	require.Equal(t, "abcd", string(got))
+}
@@ -0,0 +1,282 @@
+package bufferz
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultSegmentSize = 4 << 20
+	defaultHotWindow   = 64 << 10
+
+	segmentExt = ".log"
+)
+
+// FileOptions configures a MultiReader that stores its data in segment files
+type FileOptions struct {
+	// SegmentSize is the size at which the current segment file is finished and a new one started, defaults to 4MiB
+	SegmentSize int64
+	// HotWindow is the number of most recently written bytes also kept in memory, defaults to 64KiB.
+	// Readers that keep up with the writer are served from memory.
+	HotWindow int
+}
+
+// NewFileMultiReader returns a MultiReader that stores its data in segment files in dir instead of memory.
+// Data already stored in dir is kept and written to after, so a closed MultiReader can be reopened to read
+// the data of a previous process.
+func NewFileMultiReader(dir string, opts FileOptions) (*MultiReader, error) {
+	if opts.SegmentSize <= 0 {
+		opts.SegmentSize = defaultSegmentSize
+	}
+	if opts.HotWindow <= 0 {
+		opts.HotWindow = defaultHotWindow
+	}
+	data, err := openSegments(dir, opts)
+	if err != nil {
+		return nil, err
+	}
+	return newMultiReader(data), nil
+}
+
+// segment is a file holding the data from offset start
+type segment struct {
+	start int64
+	size  int64
+	path  string
+}
+
+// segmentStorage appends data to the last of a sequence of segment files named after the offset of their first
+// byte, so any offset can be located without an index. Finished segments are never written to again.
+// Discarded data is released a segment at a time, once every byte of a finished segment has been discarded
+// its file is removed.
+type segmentStorage struct {
+	dir  string
+	opts FileOptions
+
+	segments []segment
+	// first is the offset of the oldest byte kept
+	first int64
+	// current is the last segment opened for appending, nil until the first write
+	current *os.File
+	total   int64
+
+	// hot holds the last bytes written, starting at offset total-len(hot)
+	hot []byte
+}
+
+func openSegments(dir string, opts FileOptions) (*segmentStorage, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("os.MkdirAll: %w", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("os.ReadDir: %w", err)
+	}
+	s := &segmentStorage{dir: dir, opts: opts}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || filepath.Ext(name) != segmentExt {
+			continue
+		}
+		start, err := strconv.ParseInt(strings.TrimSuffix(name, segmentExt), 10, 64)
+		if err != nil {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, fmt.Errorf("stat segment: %w", err)
+		}
+		s.segments = append(s.segments, segment{start: start, size: info.Size(), path: filepath.Join(dir, name)})
+	}
+	sort.Slice(s.segments, func(i, j int) bool { return s.segments[i].start < s.segments[j].start })
+	// segments before the first one kept have been removed
+	if len(s.segments) > 0 {
+		s.first = s.segments[0].start
+		s.total = s.first
+	}
+	for i, seg := range s.segments {
+		if seg.start != s.total {
+			return nil, fmt.Errorf("segment %d does not follow offset %d", i, s.total)
+		}
+		s.total += seg.size
+	}
+
+	// warm up the hot window with the tail of the stored data
+	hotStart := s.total - int64(opts.HotWindow)
+	if hotStart < s.first {
+		hotStart = s.first
+	}
+	hot := make([]byte, s.total-hotStart)
+	var r spanReader
+	defer r.close()
+	for read := 0; read < len(hot); {
+		n, err := r.read(hot[read:], s.locate(hotStart+int64(read), len(hot)-read))
+		if err != nil {
+			return nil, err
+		}
+		read += n
+	}
+	s.hot = hot
+	return s, nil
+}
+
+func (s *segmentStorage) write(p []byte) error {
+	for len(p) > 0 {
+		if s.current == nil || s.segments[len(s.segments)-1].size >= s.opts.SegmentSize {
+			if err := s.rotate(); err != nil {
+				return err
+			}
+		}
+		last := &s.segments[len(s.segments)-1]
+		chunk := p
+		if room := s.opts.SegmentSize - last.size; int64(len(chunk)) > room {
+			chunk = chunk[:room]
+		}
+		n, err := s.current.Write(chunk)
+		last.size += int64(n)
+		s.total += int64(n)
+		s.appendHot(chunk[:n])
+		if err != nil {
+			return fmt.Errorf("write segment: %w", err)
+		}
+		p = p[n:]
+	}
+	return nil
+}
+
+// rotate finishes the current segment and starts a new one at the current end of the data,
+// unless the last segment still has room, which happens after reopening
+func (s *segmentStorage) rotate() error {
+	if s.current != nil {
+		if err := s.current.Close(); err != nil {
+			return fmt.Errorf("close segment: %w", err)
+		}
+		s.current = nil
+	}
+	if n := len(s.segments); n > 0 && s.segments[n-1].size < s.opts.SegmentSize {
+		f, err := os.OpenFile(s.segments[n-1].path, os.O_WRONLY|os.O_APPEND, 0600)
+		if err != nil {
+			return fmt.Errorf("open segment: %w", err)
+		}
+		s.current = f
+		return nil
+	}
+	path := filepath.Join(s.dir, fmt.Sprintf("%020d%s", s.total, segmentExt))
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return fmt.Errorf("create segment: %w", err)
+	}
+	s.current = f
+	s.segments = append(s.segments, segment{start: s.total, path: path})
+	return nil
+}
+
+// appendHot adds p to the hot window. The window is trimmed once it holds twice its size, so trimming,
+// which copies to release the dropped bytes, does not happen on every write.
+func (s *segmentStorage) appendHot(p []byte) {
+	s.hot = append(s.hot, p...)
+	if len(s.hot) > 2*s.opts.HotWindow {
+		s.hot = append([]byte(nil), s.hot[len(s.hot)-s.opts.HotWindow:]...)
+	}
+}
+
+func (s *segmentStorage) size() int64 {
+	return s.total
+}
+
+func (s *segmentStorage) start() int64 {
+	return s.first
+}
+
+func (s *segmentStorage) discard(off int64) error {
+	s.first = off
+	// the last segment is kept to append to
+	removed := 0
+	for removed < len(s.segments)-1 && s.segments[removed].start+s.segments[removed].size <= off {
+		if err := os.Remove(s.segments[removed].path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("remove segment: %w", err)
+		}
+		removed++
+	}
+	s.segments = s.segments[removed:]
+	return nil
+}
+
+// locate serves data in the hot window from memory, and the rest from the segment holding off.
+// The hot window is never modified in place, appending or trimming it leaves the located bytes as they are.
+func (s *segmentStorage) locate(off int64, n int) span {
+	hotStart := s.total - int64(len(s.hot))
+	if off >= hotStart {
+		data := s.hot[off-hotStart:][:n]
+		return span{data: data, size: n}
+	}
+	i := sort.Search(len(s.segments), func(i int) bool {
+		return s.segments[i].start+s.segments[i].size > off
+	})
+	seg := s.segments[i]
+	if remaining := seg.start + seg.size - off; int64(n) > remaining {
+		n = int(remaining)
+	}
+	return span{path: seg.path, off: off - seg.start, size: n}
+}
+
+// span is data located under the lock of a MultiReader to be read after it is released, either bytes in memory
+// or a range of a segment file. A segment file removed after its data was located stays readable while it is open.
+type span struct {
+	data []byte
+	path string
+	off  int64
+	size int
+}
+
+// spanReader reads spans, keeping the segment file it last read open so reading a segment a chunk at a time
+// opens it once. It is not safe for concurrent use.
+type spanReader struct {
+	f *os.File
+}
+
+// read reads the span into p, which must hold at least s.size bytes
+func (r *spanReader) read(p []byte, s span) (int, error) {
+	if s.path == "" {
+		return copy(p, s.data), nil
+	}
+	if r.f == nil || r.f.Name() != s.path {
+		r.close()
+		f, err := os.Open(s.path)
+		if err != nil {
+			return 0, fmt.Errorf("open segment: %w", err)
+		}
+		r.f = f
+	}
+	n, err := r.f.ReadAt(p[:s.size], s.off)
+	if err != nil && !(errors.Is(err, io.EOF) && n == s.size) {
+		return n, fmt.Errorf("read segment: %w", err)
+	}
+	return n, nil
+}
+
+// close closes the segment file kept open, reading another span opens it again
+func (r *spanReader) close() {
+	if r.f != nil {
+		r.f.Close()
+		r.f = nil
+	}
+}
+
+func (s *segmentStorage) closeWriter() error {
+	if s.current == nil {
+		return nil
+	}
+	err := s.current.Close()
+	s.current = nil
+	if err != nil {
+		return fmt.Errorf("close segment: %w", err)
+	}
+	return nil
+}
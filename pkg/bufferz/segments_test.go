@@ -0,0 +1,195 @@
+package bufferz
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_FileMultiReader_ReadsAcrossSegments(t *testing.T) {
+	dir := t.TempDir()
+	multireader, err := NewFileMultiReader(dir, FileOptions{SegmentSize: 8, HotWindow: 4})
+	require.NoError(t, err)
+
+	input := []byte("0123456789abcdefghijklmnopqrstuvwxyz")
+	// uneven writes so they straddle segment boundaries
+	for _, chunk := range [][]byte{input[:5], input[5:19], input[19:]} {
+		_, err := multireader.Write(chunk)
+		require.NoError(t, err)
+	}
+	require.NoError(t, multireader.Close())
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 5)
+	require.Equal(t, int64(len(input)), multireader.Len())
+
+	got, err := io.ReadAll(multireader.GetReader(context.Background()))
+	require.NoError(t, err)
+	require.Equal(t, input, got)
+
+	for _, offset := range []int64{0, 7, 8, 20, 33, 36} {
+		got, err := io.ReadAll(multireader.GetReaderAt(context.Background(), offset, true))
+		require.NoError(t, err)
+		require.Equal(t, input[offset:], got)
+	}
+}
+
+func Test_FileMultiReader_FollowingReader(t *testing.T) {
+	multireader, err := NewFileMultiReader(t.TempDir(), FileOptions{SegmentSize: 16, HotWindow: 8})
+	require.NoError(t, err)
+
+	done := make(chan []byte)
+	go func() {
+		got, _ := io.ReadAll(multireader.GetReader(context.Background()))
+		done <- got
+	}()
+
+	var want bytes.Buffer
+	for i := 0; i < 100; i++ {
+		line := []byte("line of output\n")
+		want.Write(line)
+		_, err := multireader.Write(line)
+		require.NoError(t, err)
+	}
+	require.NoError(t, multireader.Close())
+	require.Equal(t, want.Bytes(), <-done)
+}
+
+func Test_FileMultiReader_Reopen(t *testing.T) {
+	dir := t.TempDir()
+	multireader, err := NewFileMultiReader(dir, FileOptions{SegmentSize: 8})
+	require.NoError(t, err)
+	_, err = multireader.Write([]byte("first\nrun\n"))
+	require.NoError(t, err)
+	require.NoError(t, multireader.Close())
+
+	reopened, err := NewFileMultiReader(dir, FileOptions{SegmentSize: 8})
+	require.NoError(t, err)
+	require.Equal(t, int64(10), reopened.Len())
+	require.Equal(t, int64(6), reopened.LineOffset(1))
+	_, err = reopened.Write([]byte("second\n"))
+	require.NoError(t, err)
+	require.NoError(t, reopened.Close())
+
+	got, err := io.ReadAll(reopened.GetReader(context.Background()))
+	require.NoError(t, err)
+	require.Equal(t, "first\nrun\nsecond\n", string(got))
+}
+
+func Test_FileMultiReader_LimitRingRemovesSegments(t *testing.T) {
+	dir := t.TempDir()
+	multireader, err := NewFileMultiReader(dir, FileOptions{SegmentSize: 4, HotWindow: 2})
+	require.NoError(t, err)
+	multireader.SetLimit(6, LimitRing)
+
+	input := []byte("0123456789abcdef")
+	for _, chunk := range [][]byte{input[:3], input[3:6], input[6:9], input[9:12], input[12:15], input[15:]} {
+		_, err := multireader.Write(chunk)
+		require.NoError(t, err)
+	}
+	require.NoError(t, multireader.Close())
+
+	got, err := io.ReadAll(multireader.GetReader(context.Background()))
+	require.NoError(t, err)
+	require.Equal(t, input[10:], got)
+
+	// only the segments holding data that is kept remain
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	reopened, err := NewFileMultiReader(dir, FileOptions{SegmentSize: 4, HotWindow: 2})
+	require.NoError(t, err)
+	require.NoError(t, reopened.Close())
+	require.Equal(t, int64(8), reopened.Start())
+	got, err = io.ReadAll(reopened.GetReader(context.Background()))
+	require.NoError(t, err)
+	require.Equal(t, input[8:], got)
+}
+
+func Test_FileMultiReader_ReaderKeepsSegmentOpen(t *testing.T) {
+	multireader, err := NewFileMultiReader(t.TempDir(), FileOptions{SegmentSize: 8, HotWindow: 1})
+	require.NoError(t, err)
+	_, err = multireader.Write([]byte("0123456789abcdef!"))
+	require.NoError(t, err)
+	require.NoError(t, multireader.Close())
+
+	reader := multireader.GetReaderAt(context.Background(), 0, true)
+	buf := make([]byte, 1)
+	var files []*os.File
+	var got []byte
+	for {
+		n, err := reader.Read(buf)
+		got = append(got, buf[:n]...)
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		if len(files) == 0 || files[len(files)-1] != reader.file.f {
+			files = append(files, reader.file.f)
+		}
+	}
+	require.Equal(t, "0123456789abcdef!", string(got))
+	// each segment is opened once, the last byte is read from the hot window
+	require.Len(t, files, 2)
+	require.Nil(t, reader.file.f)
+}
+
+func Test_FileMultiReader_LimitRingWhileReading(t *testing.T) {
+	multireader, err := NewFileMultiReader(t.TempDir(), FileOptions{SegmentSize: 16, HotWindow: 4})
+	require.NoError(t, err)
+	multireader.SetLimit(64, LimitRing)
+
+	input := make([]byte, 64<<10)
+	for i := range input {
+		input[i] = byte(i % 251)
+	}
+	type result struct {
+		got     []byte
+		skipped int64
+		err     error
+	}
+	done := make(chan result)
+	for i := 0; i < 4; i++ {
+		go func() {
+			// small reads so segments are removed while readers are in them
+			reader := multireader.GetReaderAt(context.Background(), 0, true)
+			var got []byte
+			buf := make([]byte, 3)
+			for {
+				n, err := reader.Read(buf)
+				got = append(got, buf[:n]...)
+				if err != nil {
+					if err == io.EOF {
+						err = nil
+					}
+					done <- result{got: got, skipped: reader.Skipped(), err: err}
+					return
+				}
+			}
+		}()
+	}
+
+	for off := 0; off < len(input); off += 7 {
+		end := off + 7
+		if end > len(input) {
+			end = len(input)
+		}
+		_, err := multireader.Write(input[off:end])
+		require.NoError(t, err)
+	}
+	require.NoError(t, multireader.Close())
+
+	for i := 0; i < 4; i++ {
+		res := <-done
+		require.NoError(t, res.err)
+		// whatever was read before its data was dropped is correct, and the rest was skipped
+		require.Equal(t, int64(len(input)), res.skipped+int64(len(res.got)))
+		require.Equal(t, input[len(input)-64:], res.got[len(res.got)-64:])
+	}
+}
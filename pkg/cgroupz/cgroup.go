@@ -1,10 +1,13 @@
 package cgroupz
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
+	"syscall"
 	"time"
 )
 
@@ -29,6 +32,57 @@ func (c *CgroupController) AddProcess(pid int) error {
 	return AddProcess(c.Path, pid)
 }
 
+// Procs returns the pids of the processes in the cgroup
+func (c *CgroupController) Procs() ([]int, error) {
+	data, err := os.ReadFile(filepath.Join(c.Path, "cgroup.procs"))
+	if err != nil {
+		return nil, fmt.Errorf("read cgroup.procs: %w", err)
+	}
+	var pids []int
+	for _, field := range strings.Fields(string(data)) {
+		pid, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, fmt.Errorf("parse cgroup.procs: %w", err)
+		}
+		pids = append(pids, pid)
+	}
+	return pids, nil
+}
+
+// Kill sends SIGKILL to every process in the cgroup, including processes forked while killing.
+// Kernels without cgroup.kill fall back to killing the listed processes until none are left.
+func (c *CgroupController) Kill() error {
+	f, err := os.OpenFile(filepath.Join(c.Path, "cgroup.kill"), os.O_WRONLY, 0)
+	if err == nil {
+		_, err = f.Write([]byte("1"))
+		closeErr := f.Close()
+		if err == nil && closeErr == nil {
+			return nil
+		}
+	}
+
+	duration := 10 * time.Millisecond
+	for i := 0; i < 5; i++ {
+		pids, err := c.Procs()
+		if err != nil {
+			return err
+		}
+		alive := 0
+		for _, pid := range pids {
+			// ESRCH means the process is already gone
+			if err := syscall.Kill(pid, syscall.SIGKILL); err == nil {
+				alive++
+			}
+		}
+		if alive == 0 {
+			return nil
+		}
+		time.Sleep(duration)
+		duration *= 2
+	}
+	return errors.New("processes left in cgroup after kill")
+}
+
 // Close deletes the cgroup hierarchy managed by the contorller
 func (c *CgroupController) Close() error {
 	return cleanUp(c.Path)
@@ -52,10 +106,10 @@ func cleanUp(path string) error {
 // After this successfully returns, it is the caller's responsibility to call Close to
 // clean up the existing resources.
 func New(name string, mountPoint string, limits ResourceLimit) (*CgroupController, error) {
-	// ensure cpu, mem, and io is available
+	// ensure cpu, mem, io and pids is available
 	err := os.WriteFile(
 		filepath.Join(mountPoint, "cgroup.subtree_control"),
-		[]byte("+cpu +memory +io"),
+		[]byte("+cpu +memory +io +pids"),
 		0644,
 	)
 	if err != nil {
@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
@@ -90,3 +92,37 @@ func setupMount(t *testing.T) string {
 	require.NoError(t, err)
 	return dir
 }
+
+func Test_Procs(t *testing.T) {
+	dir := setupMount(t)
+	t.Cleanup(func() { cleanUp(dir) })
+	ctrl, err := New(uuid.New().String(), dir, ResourceLimit{})
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(ctrl.Path, "cgroup.procs"), []byte("12\n345\n"), 0644))
+	pids, err := ctrl.Procs()
+	require.NoError(t, err)
+	require.Equal(t, []int{12, 345}, pids)
+}
+
+func Test_Kill_FallsBackToProcs(t *testing.T) {
+	dir := setupMount(t)
+	t.Cleanup(func() { cleanUp(dir) })
+	ctrl, err := New(uuid.New().String(), dir, ResourceLimit{})
+	require.NoError(t, err)
+
+	cmd := exec.Command("sleep", "10")
+	require.NoError(t, cmd.Start())
+	waitErr := make(chan error, 1)
+	go func() { waitErr <- cmd.Wait() }()
+	require.NoError(t, ctrl.AddProcess(cmd.Process.Pid))
+
+	// the test mount has no cgroup.kill, the process is killed by pid
+	_ = ctrl.Kill()
+	select {
+	case err := <-waitErr:
+		require.Error(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("process was not killed")
+	}
+}
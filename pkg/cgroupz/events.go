@@ -0,0 +1,141 @@
+package cgroupz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+	"unsafe"
+)
+
+// MemoryEvents counts the memory events of a cgroup, read from memory.events
+type MemoryEvents struct {
+	// Max is the number of times the cgroup's usage was about to go over memory.max
+	Max uint64
+	// OOM is the number of times the cgroup's usage reached memory.max and an allocation failed
+	OOM uint64
+	// OOMKill is the number of processes of the cgroup killed by the OOM killer
+	OOMKill uint64
+}
+
+// MemoryEvents reads the memory events of the cgroup managed by the controller. A missing memory.events
+// counts no events.
+func (c *CgroupController) MemoryEvents() (MemoryEvents, error) {
+	values, err := readKeyed(filepath.Join(c.Path, "memory.events"))
+	if err != nil {
+		return MemoryEvents{}, err
+	}
+	return MemoryEvents{
+		Max:     values["max"],
+		OOM:     values["oom"],
+		OOMKill: values["oom_kill"],
+	}, nil
+}
+
+// WatchMemoryEvents calls fn with the memory events of the cgroup once, and again every time they change
+// until ctx is done or memory.events is removed along with the cgroup. The kernel notifies changes of
+// memory.events through inotify, so nothing is polled.
+func (c *CgroupController) WatchMemoryEvents(ctx context.Context, fn func(MemoryEvents)) error {
+	var last MemoryEvents
+	first := true
+	return watchFile(ctx, filepath.Join(c.Path, "memory.events"), func() (bool, error) {
+		events, err := c.MemoryEvents()
+		if err != nil {
+			return false, err
+		}
+		if first || events != last {
+			first = false
+			last = events
+			fn(events)
+		}
+		return false, nil
+	})
+}
+
+// Populated reports whether any process is left in the cgroup or its descendants, read from cgroup.events.
+// A missing cgroup.events has no processes.
+func (c *CgroupController) Populated() (bool, error) {
+	values, err := readKeyed(filepath.Join(c.Path, "cgroup.events"))
+	if err != nil {
+		return false, err
+	}
+	return values["populated"] != 0, nil
+}
+
+// WaitEmpty blocks until no process is left in the cgroup or its descendants, or returns the error of ctx once it
+// is done. The kernel notifies changes of cgroup.events through inotify, so nothing is polled. A removed cgroup
+// is empty.
+func (c *CgroupController) WaitEmpty(ctx context.Context) error {
+	empty := false
+	err := watchFile(ctx, filepath.Join(c.Path, "cgroup.events"), func() (bool, error) {
+		populated, err := c.Populated()
+		empty = err == nil && !populated
+		return empty, err
+	})
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !empty && ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return nil
+}
+
+// watchFile calls fn once, and again every time the file at path is modified, until fn returns true or an error,
+// ctx is done or the file is removed
+func watchFile(ctx context.Context, path string, fn func() (bool, error)) error {
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC | syscall.IN_NONBLOCK)
+	if err != nil {
+		return fmt.Errorf("inotify_init1: %w", err)
+	}
+	// a non-blocking file is read through the runtime poller, so closing it ends a pending read
+	f := os.NewFile(uintptr(fd), "inotify")
+	if _, err := syscall.InotifyAddWatch(fd, path, syscall.IN_MODIFY); err != nil {
+		f.Close()
+		return fmt.Errorf("inotify_add_watch: %w", err)
+	}
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-done:
+		}
+		f.Close()
+	}()
+
+	// changes made before the watch was added are not notified
+	if stop, err := fn(); stop || err != nil {
+		return err
+	}
+
+	buf := make([]byte, 4096)
+	for {
+		n, err := f.Read(buf)
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, os.ErrClosed) {
+				return nil
+			}
+			return fmt.Errorf("read inotify: %w", err)
+		}
+		removed := false
+		for offset := 0; offset+syscall.SizeofInotifyEvent <= n; {
+			event := (*syscall.InotifyEvent)(unsafe.Pointer(&buf[offset]))
+			if event.Mask&syscall.IN_IGNORED != 0 {
+				removed = true
+			}
+			offset += syscall.SizeofInotifyEvent + int(event.Len)
+		}
+		if removed {
+			return nil
+		}
+		if stop, err := fn(); stop || err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package cgroupz
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_MemoryEvents(t *testing.T) {
+	dir := setupMount(t)
+	t.Cleanup(func() { cleanUp(dir) })
+	ctrl, err := New(uuid.New().String(), dir, ResourceLimit{})
+	require.NoError(t, err)
+
+	events, err := ctrl.MemoryEvents()
+	require.NoError(t, err)
+	require.Equal(t, MemoryEvents{}, events)
+
+	writeMemoryEvents(t, ctrl, "low 0\nhigh 0\nmax 4\noom 2\noom_kill 1\n")
+	events, err = ctrl.MemoryEvents()
+	require.NoError(t, err)
+	require.Equal(t, MemoryEvents{Max: 4, OOM: 2, OOMKill: 1}, events)
+}
+
+func Test_WatchMemoryEvents(t *testing.T) {
+	dir := setupMount(t)
+	t.Cleanup(func() { cleanUp(dir) })
+	ctrl, err := New(uuid.New().String(), dir, ResourceLimit{})
+	require.NoError(t, err)
+	writeMemoryEvents(t, ctrl, "low 0\nhigh 0\nmax 0\noom 0\noom_kill 0\n")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changes := make(chan MemoryEvents, 16)
+	watchErr := make(chan error, 1)
+	go func() { watchErr <- ctrl.WatchMemoryEvents(ctx, func(events MemoryEvents) { changes <- events }) }()
+
+	require.Equal(t, MemoryEvents{}, receive(t, changes))
+	writeMemoryEvents(t, ctrl, "low 0\nhigh 0\nmax 1\noom 1\noom_kill 1\n")
+	require.Equal(t, MemoryEvents{Max: 1, OOM: 1, OOMKill: 1}, receive(t, changes))
+
+	cancel()
+	require.NoError(t, <-watchErr)
+}
+
+func Test_WatchMemoryEvents_RemovedCgroup(t *testing.T) {
+	dir := setupMount(t)
+	t.Cleanup(func() { cleanUp(dir) })
+	ctrl, err := New(uuid.New().String(), dir, ResourceLimit{})
+	require.NoError(t, err)
+
+	err = ctrl.WatchMemoryEvents(context.Background(), func(MemoryEvents) {})
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	writeMemoryEvents(t, ctrl, "oom_kill 0\n")
+	watchErr := make(chan error, 1)
+	changes := make(chan MemoryEvents, 16)
+	go func() {
+		watchErr <- ctrl.WatchMemoryEvents(context.Background(), func(events MemoryEvents) { changes <- events })
+	}()
+	receive(t, changes)
+	require.NoError(t, ctrl.Close())
+
+	select {
+	case err := <-watchErr:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("watch did not end once the cgroup was removed")
+	}
+}
+
+func writeMemoryEvents(t *testing.T, ctrl *CgroupController, content string) {
+	require.NoError(t, os.WriteFile(filepath.Join(ctrl.Path, "memory.events"), []byte(content), 0644))
+}
+
+func receive(t *testing.T, changes <-chan MemoryEvents) MemoryEvents {
+	select {
+	case events := <-changes:
+		return events
+	case <-time.After(5 * time.Second):
+		t.Fatal("no memory events")
+		return MemoryEvents{}
+	}
+}
+
+func Test_WaitEmpty(t *testing.T) {
+	dir := setupMount(t)
+	t.Cleanup(func() { cleanUp(dir) })
+	ctrl, err := New(uuid.New().String(), dir, ResourceLimit{})
+	require.NoError(t, err)
+	writeCgroupEvents(t, ctrl, "populated 1\nfrozen 0\n")
+
+	populated, err := ctrl.Populated()
+	require.NoError(t, err)
+	require.True(t, populated)
+
+	// a populated cgroup is waited on until ctx is done
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, ctrl.WaitEmpty(ctx), context.DeadlineExceeded)
+
+	waitErr := make(chan error, 1)
+	go func() { waitErr <- ctrl.WaitEmpty(context.Background()) }()
+	select {
+	case err := <-waitErr:
+		t.Fatalf("WaitEmpty returned while the cgroup was populated: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+	writeCgroupEvents(t, ctrl, "populated 0\nfrozen 0\n")
+	select {
+	case err := <-waitErr:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("WaitEmpty did not return once the cgroup was empty")
+	}
+
+	// an empty or removed cgroup returns at once
+	require.NoError(t, ctrl.WaitEmpty(context.Background()))
+	require.NoError(t, ctrl.Close())
+	require.NoError(t, ctrl.WaitEmpty(context.Background()))
+}
+
+func writeCgroupEvents(t *testing.T, ctrl *CgroupController, content string) {
+	require.NoError(t, os.WriteFile(filepath.Join(ctrl.Path, "cgroup.events"), []byte(content), 0644))
+}
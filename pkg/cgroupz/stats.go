@@ -0,0 +1,223 @@
+package cgroupz
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Stats is the resource usage accounted by a cgroup. Values of files the kernel does not provide, such as
+// memory.peak before Linux 5.19 or pids.current without the pids controller, are left zero.
+type Stats struct {
+	// from cpu.stat, in microseconds
+	CPUUsageUsec  uint64
+	CPUUserUsec   uint64
+	CPUSystemUsec uint64
+	// periods in which the cgroup was throttled and the time it spent throttled, non-zero with a cpu.max
+	CPUThrottledPeriods uint64
+	CPUThrottledUsec    uint64
+
+	// memory.current and memory.peak in bytes
+	MemoryCurrent uint64
+	MemoryPeak    uint64
+	// MemoryStat holds the fields of memory.stat, such as anon and file, in bytes or counts
+	MemoryStat map[string]uint64
+
+	// IO holds the fields of io.stat for each device the cgroup used
+	IO []IOStat
+
+	// PidsCurrent is the number of processes and threads in the cgroup
+	PidsCurrent uint64
+}
+
+// IOStat is the io accounted to a cgroup on one block device
+type IOStat struct {
+	Maj    int
+	Min    int
+	RBytes uint64
+	WBytes uint64
+	RIOs   uint64
+	WIOs   uint64
+}
+
+// ReadBytes returns the bytes read across every device
+func (s Stats) ReadBytes() uint64 {
+	var n uint64
+	for _, io := range s.IO {
+		n += io.RBytes
+	}
+	return n
+}
+
+// WrittenBytes returns the bytes written across every device
+func (s Stats) WrittenBytes() uint64 {
+	var n uint64
+	for _, io := range s.IO {
+		n += io.WBytes
+	}
+	return n
+}
+
+// Rates is the resource usage of a cgroup between two reads of its Stats
+type Rates struct {
+	// CPUPercent is the cpu time used as a percentage of one cpu, above 100 when more than one cpu was used
+	CPUPercent         float64
+	ReadBytesPerSec    float64
+	WrittenBytesPerSec float64
+}
+
+// RatesSince returns the usage between prev and s, which were read elapsed apart. Counters that went backwards,
+// as they do when a cgroup is recreated, count as unused.
+func (s Stats) RatesSince(prev Stats, elapsed time.Duration) Rates {
+	seconds := elapsed.Seconds()
+	if seconds <= 0 {
+		return Rates{}
+	}
+	return Rates{
+		CPUPercent:         float64(delta(prev.CPUUsageUsec, s.CPUUsageUsec)) / 1e6 / seconds * 100,
+		ReadBytesPerSec:    float64(delta(prev.ReadBytes(), s.ReadBytes())) / seconds,
+		WrittenBytesPerSec: float64(delta(prev.WrittenBytes(), s.WrittenBytes())) / seconds,
+	}
+}
+
+func delta(prev, cur uint64) uint64 {
+	if cur < prev {
+		return 0
+	}
+	return cur - prev
+}
+
+// Stats reads the resource usage of the cgroup managed by the controller
+func (c *CgroupController) Stats() (Stats, error) {
+	return ReadStats(c.Path)
+}
+
+// ReadStats reads the resource usage of the cgroup at path
+func ReadStats(path string) (Stats, error) {
+	// missing files are left zero, but not a missing cgroup
+	if _, err := os.Stat(path); err != nil {
+		return Stats{}, fmt.Errorf("os.Stat: %w", err)
+	}
+	var stats Stats
+
+	cpu, err := readKeyed(filepath.Join(path, "cpu.stat"))
+	if err != nil {
+		return Stats{}, err
+	}
+	stats.CPUUsageUsec = cpu["usage_usec"]
+	stats.CPUUserUsec = cpu["user_usec"]
+	stats.CPUSystemUsec = cpu["system_usec"]
+	stats.CPUThrottledPeriods = cpu["nr_throttled"]
+	stats.CPUThrottledUsec = cpu["throttled_usec"]
+
+	if stats.MemoryCurrent, err = readValue(filepath.Join(path, "memory.current")); err != nil {
+		return Stats{}, err
+	}
+	if stats.MemoryPeak, err = readValue(filepath.Join(path, "memory.peak")); err != nil {
+		return Stats{}, err
+	}
+	if stats.MemoryStat, err = readKeyed(filepath.Join(path, "memory.stat")); err != nil {
+		return Stats{}, err
+	}
+	if stats.IO, err = readIOStat(filepath.Join(path, "io.stat")); err != nil {
+		return Stats{}, err
+	}
+	if stats.PidsCurrent, err = readValue(filepath.Join(path, "pids.current")); err != nil {
+		return Stats{}, err
+	}
+	return stats, nil
+}
+
+// readFile returns the contents of a cgroup file, nil if it does not exist
+func readFile(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", filepath.Base(path), err)
+	}
+	return data, nil
+}
+
+// readValue parses a file holding a single value
+func readValue(path string) (uint64, error) {
+	data, err := readFile(path)
+	if err != nil || data == nil {
+		return 0, err
+	}
+	value, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parse %s: %w", filepath.Base(path), err)
+	}
+	return value, nil
+}
+
+// readKeyed parses a flat keyed file of "key value" lines
+func readKeyed(path string) (map[string]uint64, error) {
+	data, err := readFile(path)
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]uint64)
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("parse %s: malformed line %q", filepath.Base(path), line)
+		}
+		value, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", filepath.Base(path), err)
+		}
+		values[fields[0]] = value
+	}
+	return values, nil
+}
+
+// readIOStat parses io.stat, which has a line of "maj:min key=value ..." for each device
+func readIOStat(path string) ([]IOStat, error) {
+	data, err := readFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var stats []IOStat
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		var stat IOStat
+		if _, err := fmt.Sscanf(fields[0], "%d:%d", &stat.Maj, &stat.Min); err != nil {
+			return nil, fmt.Errorf("parse io.stat: malformed device %q", fields[0])
+		}
+		for _, field := range fields[1:] {
+			key, raw, ok := strings.Cut(field, "=")
+			if !ok {
+				return nil, fmt.Errorf("parse io.stat: malformed field %q", field)
+			}
+			value, err := strconv.ParseUint(raw, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("parse io.stat: %w", err)
+			}
+			switch key {
+			case "rbytes":
+				stat.RBytes = value
+			case "wbytes":
+				stat.WBytes = value
+			case "rios":
+				stat.RIOs = value
+			case "wios":
+				stat.WIOs = value
+			}
+		}
+		stats = append(stats, stat)
+	}
+	return stats, nil
+}
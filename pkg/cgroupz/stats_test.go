@@ -0,0 +1,99 @@
+package cgroupz
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Stats(t *testing.T) {
+	dir := setupMount(t)
+	t.Cleanup(func() { cleanUp(dir) })
+	ctrl, err := New(uuid.New().String(), dir, ResourceLimit{})
+	require.NoError(t, err)
+
+	files := map[string]string{
+		"cpu.stat":       "usage_usec 1500000\nuser_usec 1000000\nsystem_usec 500000\nnr_periods 0\nnr_throttled 3\nthrottled_usec 42\n",
+		"memory.current": "4096\n",
+		"memory.peak":    "1048576\n",
+		"memory.stat":    "anon 2048\nfile 1024\npgfault 7\n",
+		"io.stat":        "8:0 rbytes=100 wbytes=200 rios=1 wios=2 dbytes=0 dios=0\n8:16 rbytes=10 wbytes=20 rios=3 wios=4 dbytes=0 dios=0\n",
+		"pids.current":   "3\n",
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(ctrl.Path, name), []byte(content), 0644))
+	}
+
+	stats, err := ctrl.Stats()
+	require.NoError(t, err)
+	require.Equal(t, Stats{
+		CPUUsageUsec:        1500000,
+		CPUUserUsec:         1000000,
+		CPUSystemUsec:       500000,
+		CPUThrottledPeriods: 3,
+		CPUThrottledUsec:    42,
+		MemoryCurrent:       4096,
+		MemoryPeak:          1048576,
+		MemoryStat:          map[string]uint64{"anon": 2048, "file": 1024, "pgfault": 7},
+		IO: []IOStat{
+			{Maj: 8, Min: 0, RBytes: 100, WBytes: 200, RIOs: 1, WIOs: 2},
+			{Maj: 8, Min: 16, RBytes: 10, WBytes: 20, RIOs: 3, WIOs: 4},
+		},
+		PidsCurrent: 3,
+	}, stats)
+	require.Equal(t, uint64(110), stats.ReadBytes())
+	require.Equal(t, uint64(220), stats.WrittenBytes())
+}
+
+func Test_Stats_MissingFiles(t *testing.T) {
+	dir := setupMount(t)
+	t.Cleanup(func() { cleanUp(dir) })
+	ctrl, err := New(uuid.New().String(), dir, ResourceLimit{})
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(ctrl.Path, "memory.current"), []byte("4096\n"), 0644))
+	stats, err := ctrl.Stats()
+	require.NoError(t, err)
+	require.Equal(t, uint64(4096), stats.MemoryCurrent)
+	require.Zero(t, stats.MemoryPeak)
+	require.Zero(t, stats.CPUUsageUsec)
+	require.Empty(t, stats.IO)
+}
+
+func Test_Stats_Malformed(t *testing.T) {
+	dir := setupMount(t)
+	t.Cleanup(func() { cleanUp(dir) })
+	ctrl, err := New(uuid.New().String(), dir, ResourceLimit{})
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(ctrl.Path, "io.stat"), []byte("8:0 rbytes\n"), 0644))
+	_, err = ctrl.Stats()
+	require.Error(t, err)
+}
+
+func Test_Stats_RemovedCgroup(t *testing.T) {
+	dir := setupMount(t)
+	t.Cleanup(func() { cleanUp(dir) })
+	ctrl, err := New(uuid.New().String(), dir, ResourceLimit{})
+	require.NoError(t, err)
+	require.NoError(t, ctrl.Close())
+
+	_, err = ctrl.Stats()
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func Test_Stats_RatesSince(t *testing.T) {
+	prev := Stats{CPUUsageUsec: 1000000, IO: []IOStat{{RBytes: 100, WBytes: 1000}}}
+	cur := Stats{CPUUsageUsec: 4000000, IO: []IOStat{{RBytes: 300, WBytes: 1000}, {WBytes: 2000}}}
+
+	rates := cur.RatesSince(prev, 2*time.Second)
+	require.Equal(t, Rates{CPUPercent: 150, ReadBytesPerSec: 100, WrittenBytesPerSec: 1000}, rates)
+
+	// counters going backwards count as unused
+	require.Equal(t, Rates{}, prev.RatesSince(cur, time.Second))
+	require.Equal(t, Rates{}, cur.RatesSince(prev, 0))
+}
@@ -0,0 +1,74 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Parse_Invalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"* * * foo *",
+		"@every",
+	} {
+		_, err := Parse(expr)
+		require.ErrorIs(t, err, ErrInvalidExpression, expr)
+	}
+}
+
+func Test_Schedule_Next(t *testing.T) {
+	// a Friday
+	from := time.Date(2021, time.January, 1, 10, 30, 15, 0, time.UTC)
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{expr: "* * * * *", want: time.Date(2021, time.January, 1, 10, 31, 0, 0, time.UTC)},
+		{expr: "*/15 * * * *", want: time.Date(2021, time.January, 1, 10, 45, 0, 0, time.UTC)},
+		{expr: "0 9-17 * * *", want: time.Date(2021, time.January, 1, 11, 0, 0, 0, time.UTC)},
+		{expr: "0 0 * * *", want: time.Date(2021, time.January, 2, 0, 0, 0, 0, time.UTC)},
+		{expr: "@daily", want: time.Date(2021, time.January, 2, 0, 0, 0, 0, time.UTC)},
+		{expr: "@hourly", want: time.Date(2021, time.January, 1, 11, 0, 0, 0, time.UTC)},
+		{expr: "30 8 * * mon-fri", want: time.Date(2021, time.January, 4, 8, 30, 0, 0, time.UTC)},
+		{expr: "0 12 * * 7", want: time.Date(2021, time.January, 3, 12, 0, 0, 0, time.UTC)},
+		{expr: "0 0 1 mar *", want: time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 29 2 *", want: time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{expr: "10/20 10 * * *", want: time.Date(2021, time.January, 1, 10, 50, 0, 0, time.UTC)},
+		{expr: "0 0 1,15 * *", want: time.Date(2021, time.January, 15, 0, 0, 0, 0, time.UTC)},
+		// both days restricted, either matches
+		{expr: "0 0 15 * sat", want: time.Date(2021, time.January, 2, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 30 2 *", want: time.Time{}},
+	}
+	for _, test := range tests {
+		t.Run(test.expr, func(t *testing.T) {
+			schedule, err := Parse(test.expr)
+			require.NoError(t, err)
+			require.Equal(t, test.want, schedule.Next(from))
+		})
+	}
+}
+
+func Test_Schedule_NextInLocation(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	schedule, err := Parse("0 9 * * *")
+	require.NoError(t, err)
+
+	next := schedule.Next(time.Date(2021, time.January, 1, 8, 0, 0, 0, time.UTC))
+	require.Equal(t, time.Date(2021, time.January, 1, 9, 0, 0, 0, time.UTC), next)
+
+	// 8:00 UTC is already past 9:00 in loc
+	next = schedule.Next(time.Date(2021, time.January, 1, 8, 0, 0, 0, time.UTC).In(loc))
+	require.Equal(t, time.Date(2021, time.January, 2, 7, 0, 0, 0, time.UTC), next.UTC())
+}
@@ -1,19 +1,25 @@
 package jobs
 
 import (
-	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"io"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
 	"syscall"
+	"time"
 
 	"github.com/google/uuid"
 	"go.uber.org/multierr"
 
 	"job_runner/pkg/bufferz"
 	"job_runner/pkg/cgroupz"
+	"job_runner/pkg/ptyz"
 )
 
 type Status string
@@ -27,30 +33,106 @@ const (
 	StatusStopped Status = "stopped"
 	// StatusExited is set when the job exits. Exit code is available when this status is set.
 	StatusExited Status = "exited"
+	// StatusLost is set for a job that was running when the server stopped, how it ended is unknown
+	StatusLost Status = "lost"
+	// StatusOutputLimit is set when the job is killed for exceeding its output limit with OutputKill
+	StatusOutputLimit Status = "output_limit_exceeded"
+	// StatusQueued is set by Queue for a job waiting to be started
+	StatusQueued Status = "queued"
+	// StatusCancelled is set by Cancel for a job that was cancelled while queued, it never started
+	StatusCancelled Status = "cancelled"
+	// StatusPreempted is set when the job is stopped by Preempt to make room for another job
+	StatusPreempted Status = "preempted"
+	// StatusTimedOut is set when the job is stopped by Timeout for running past its deadline
+	StatusTimedOut Status = "timed_out"
+	// StatusOOMKilled is set when the job is killed by the OOM killer for exceeding its memory limit
+	StatusOOMKilled Status = "oom_killed"
+)
+
+// OutputPolicy selects what happens once a job's output exceeds Spec.OutputLimit
+type OutputPolicy string
+
+const (
+	// OutputRing keeps the most recent output, dropping the oldest output to make room. It is the default.
+	OutputRing OutputPolicy = "ring"
+	// OutputStop keeps the output up to the limit and discards the rest while the job keeps running
+	OutputStop OutputPolicy = "stop"
+	// OutputKill keeps the output up to the limit and kills the job, which ends with StatusOutputLimit
+	OutputKill OutputPolicy = "kill"
+)
+
+var (
+	// ErrNoStdin is returned when writing to the stdin of a Job that was not created with Spec.Stdin or has not started
+	ErrNoStdin = errors.New("job has no stdin")
+	// ErrNotRunning is returned when signalling a Job that has not started or has already ended
+	ErrNotRunning = errors.New("job is not running")
+	// ErrNoTTY is returned when resizing the terminal of a Job that was not created with Spec.TTY or has not started
+	ErrNoTTY = errors.New("job has no tty")
+	// ErrNotQueued is returned when cancelling a Job that is not queued
+	ErrNotQueued = errors.New("job is not queued")
+)
+
+// default window size of a job's terminal until it is resized
+const (
+	defaultRows = 24
+	defaultCols = 80
 )
 
 const cgroupMount = "/lib_cgroup" // this is mounted when the VM starts
 
+// time the processes left in a job's cgroup once the command exits have to exit after they are killed
+const killTimeout = 10 * time.Second
+
+// DefaultPath is the PATH of a command whose Spec.Env does not set one
+const DefaultPath = "/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin"
+
 // Job is a wrapper around exec.Cmd and provides additional functionality
 // such as resource limits via cgroups and support for streaming output
 // to multiple readers
 type Job struct {
-	Err    string // Err is the string returned from std err
 	Status Status
 
-	cmd     *exec.Cmd
-	command []string
+	// guards Status, the exit code and the start and end times, which are written by Start and Wait
+	// while other goroutines may be reading them
+	mu        sync.Mutex
+	exitCode  int
+	signal    syscall.Signal
+	startedAt time.Time
+	endedAt   time.Time
+	// closed once the job has ended, either when Wait returns or when Start fails
+	done chan struct{}
+	// set when the job is killed for exceeding its output limit
+	outputLimited bool
+	// set when the job is stopped by Preempt
+	preempted bool
+	// set when the job is stopped by Timeout
+	timedOut bool
+	// output truncated before the job was restored
+	outputTruncated int64
+	// read from the cgroup when the job is closed
+	usage Usage
+	// kept up to date from the cgroup while the job runs
+	memoryEvents cgroupz.MemoryEvents
+	// set when the job is killed by Kill, so the SIGKILL is not taken for the OOM killer's
+	killed bool
+	// pid of the command, reported by the utility once it has started the command
+	pid int
+
+	cmd  *exec.Cmd
+	spec Spec
 
 	// ctx for cancellation
 	ctx context.Context
 
-	// resource limit
+	// names the cgroup holding the resource limits
 	id     string
-	limits cgroupz.ResourceLimit
+	cgroup *cgroupz.CgroupController
 
-	// streaming
-	getReaderFn func(context.Context) io.Reader
-	writeCloser io.WriteCloser
+	// streaming, stdout and stderr are buffered separately
+	output    *bufferz.MultiReader
+	errOutput *bufferz.MultiReader
+	// set when the output directory could not be opened
+	outputErr error
 
 	cleanup    []io.Closer
 	goroutines []func() error
@@ -58,23 +140,198 @@ type Job struct {
 
 	stdout io.Reader
 	stderr io.Reader
+
+	// stdinMu serializes writes from concurrent callers of WriteStdin and guards stdin and tty,
+	// which are set when the job starts
+	stdinMu     sync.Mutex
+	stdin       io.WriteCloser
+	stdinClosed bool
+	// master end of the job's terminal when running with Spec.TTY
+	tty *os.File
+}
+
+// Spec describes the command run by a Job and how it is run
+type Spec struct {
+	Command []string
+	Limits  cgroupz.ResourceLimit
+	// Stdin keeps the command's stdin open so it can be written to with WriteStdin.
+	// Otherwise the command reads from the null device.
+	Stdin bool
+	// TTY runs the command under a pseudo-terminal. Stdout and stderr are both written to the terminal and
+	// streamed as SourceStdout. TTY implies Stdin.
+	TTY bool
+	// Env is the environment of the command as KEY=VALUE pairs. The command does not inherit the environment of
+	// the server, PATH is set to DefaultPath unless Env sets it.
+	Env []string
+	// Dir is the working directory of the command, defaults to the working directory of the server
+	Dir string
+	// Credential is the user and group the command runs as, defaults to the user of the server
+	Credential *Credential
+	// OutputDir stores the output on disk in OutputDir instead of memory, where it outlives the Job
+	OutputDir string
+	// OutputLimit caps the bytes of stdout and of stderr that are kept, each separately. Zero keeps all output.
+	OutputLimit int64
+	// OutputPolicy selects what happens once the output exceeds OutputLimit, defaults to OutputRing
+	OutputPolicy OutputPolicy
+}
+
+// Credential identifies the user and group a command runs as
+type Credential struct {
+	UID uint32
+	GID uint32
+}
+
+// State is a point in time snapshot of a Job
+type State struct {
+	Status   Status
+	ExitCode int
+	// Signal is the signal that stopped the job when Status is StatusStopped
+	Signal    syscall.Signal
+	StartedAt time.Time
+	EndedAt   time.Time
+	// OutputTruncated is the number of bytes of output that were dropped or discarded for exceeding the output limit
+	OutputTruncated int64
+	// Usage is the resource usage of the job, set once it has ended
+	Usage Usage
+	// MemoryEvents counts how often the job reached its memory limit and how many of its processes were OOM-killed
+	MemoryEvents cgroupz.MemoryEvents
+}
+
+// Usage summarizes the resources a job used, read from its cgroup just before the cgroup is removed
+type Usage struct {
+	// CPU is the user and system cpu time of every process of the job
+	CPU          time.Duration
+	PeakMemory   uint64
+	ReadBytes    uint64
+	WrittenBytes uint64
+}
+
+// usageOf summarizes the final stats of a job
+func usageOf(stats cgroupz.Stats) Usage {
+	return Usage{
+		CPU:          time.Duration(stats.CPUUsageUsec) * time.Microsecond,
+		PeakMemory:   stats.MemoryPeak,
+		ReadBytes:    stats.ReadBytes(),
+		WrittenBytes: stats.WrittenBytes(),
+	}
+}
+
+// Source identifies one of the output streams of a Job
+type Source string
+
+const (
+	SourceStdout Source = "stdout"
+	SourceStderr Source = "stderr"
+)
+
+// TruncatedWriter is implemented by writers passed to StreamAt that are told when output was dropped by OutputRing
+// before it could be streamed.
+type TruncatedWriter interface {
+	// Truncated is called with the number of bytes skipped before the chunk written next
+	Truncated(n int64) error
+}
+
+// StreamOptions controls which output is streamed, where streaming starts and whether it waits for new output.
+// When both tail options are set, streaming starts at whichever yields less output.
+type StreamOptions struct {
+	// Source is the output to stream, defaults to SourceStdout
+	Source Source
+	// Offset is the byte offset in the output to start streaming from
+	Offset int64
+	// TailBytes starts streaming at most TailBytes before the current end of the output, ignoring Offset
+	TailBytes int64
+	// TailLines starts streaming at the last TailLines lines of the current output, ignoring Offset
+	TailLines int
+	// Follow keeps streaming new output until the command closes
+	Follow bool
 }
 
 // New creates an un-executed Job.
-func New(ctx context.Context, command []string, limits cgroupz.ResourceLimit) Job {
-	multireader := bufferz.NewMultiReaderBuffer()
-	return Job{
-		id:          uuid.New().String(),
-		Status:      StatusUnknown,
-		command:     command,
-		limits:      limits,
-		getReaderFn: multireader.GetReader,
-		writeCloser: multireader,
-		ctx:         ctx,
-		cleanup:     []io.Closer{multireader},
+// If the output directory of spec can not be opened, the error is returned by Start.
+func New(ctx context.Context, spec Spec) *Job {
+	multireader, errMultireader, err := newOutputs(spec.OutputDir)
+	if spec.OutputLimit > 0 {
+		policy := bufferz.LimitRing
+		if spec.OutputPolicy == OutputStop || spec.OutputPolicy == OutputKill {
+			policy = bufferz.LimitStop
+		}
+		multireader.SetLimit(spec.OutputLimit, policy)
+		errMultireader.SetLimit(spec.OutputLimit, policy)
+	}
+	return &Job{
+		id:        uuid.New().String(),
+		Status:    StatusUnknown,
+		exitCode:  -1,
+		spec:      spec,
+		output:    multireader,
+		errOutput: errMultireader,
+		ctx:       ctx,
+		done:      make(chan struct{}),
+		cleanup:   []io.Closer{multireader, errMultireader},
+		outputErr: err,
 	}
 }
 
+// newOutputs returns the buffers of stdout and stderr, stored in memory or in outputDir if set.
+// On error, empty in memory buffers are returned along with it.
+func newOutputs(outputDir string) (*bufferz.MultiReader, *bufferz.MultiReader, error) {
+	if outputDir == "" {
+		return bufferz.NewMultiReaderBuffer(), bufferz.NewMultiReaderBuffer(), nil
+	}
+	stdout, err := bufferz.NewFileMultiReader(filepath.Join(outputDir, string(SourceStdout)), bufferz.FileOptions{})
+	if err != nil {
+		return bufferz.NewMultiReaderBuffer(), bufferz.NewMultiReaderBuffer(), fmt.Errorf("stdout: %w", err)
+	}
+	stderr, err := bufferz.NewFileMultiReader(filepath.Join(outputDir, string(SourceStderr)), bufferz.FileOptions{})
+	if err != nil {
+		stdout.Close()
+		return bufferz.NewMultiReaderBuffer(), bufferz.NewMultiReaderBuffer(), fmt.Errorf("stderr: %w", err)
+	}
+	return stdout, stderr, nil
+}
+
+// Restore returns a Job that has already ended in state, such as a job recorded before the server restarted.
+// The Job has no process and Start must not be called on it. Its output is read from the output directory of spec,
+// without one or if it can not be read the output is empty.
+func Restore(spec Spec, state State) *Job {
+	job := New(context.Background(), spec)
+	job.close()
+	job.Status = state.Status
+	job.exitCode = state.ExitCode
+	job.signal = state.Signal
+	job.startedAt = state.StartedAt
+	job.endedAt = state.EndedAt
+	job.outputTruncated = state.OutputTruncated
+	job.usage = state.Usage
+	job.memoryEvents = state.MemoryEvents
+	close(job.done)
+	return job
+}
+
+// Queue marks a Job that has not started as waiting to be started
+func (j *Job) Queue() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.Status == StatusUnknown && j.cmd == nil {
+		j.Status = StatusQueued
+	}
+}
+
+// Cancel ends a queued Job without starting it, Start must not be called afterwards
+func (j *Job) Cancel() error {
+	j.mu.Lock()
+	if j.Status != StatusQueued {
+		j.mu.Unlock()
+		return ErrNotQueued
+	}
+	j.Status = StatusCancelled
+	j.endedAt = time.Now()
+	j.mu.Unlock()
+	j.close()
+	close(j.done)
+	return nil
+}
+
 // Start starts the job and does not block.
 // After Start is called, Wait needs to be called to release resources and
 // set fields for the Job.
@@ -83,42 +340,144 @@ func (j *Job) Start() error {
 	if j.cmd != nil && j.cmd.Process != nil {
 		return errors.New("process already executed")
 	}
-	if err := j.start(); err != nil {
+	err := j.outputErr
+	if err == nil {
+		err = j.start()
+	}
+	if err != nil {
 		j.close()
+		j.mu.Lock()
+		j.endedAt = time.Now()
+		j.mu.Unlock()
+		close(j.done)
 		return err
 	}
 	return nil
 }
 
 func (j *Job) start() error {
-	cgroup, err := cgroupz.New(j.id, cgroupMount, j.limits)
+	cgroup, err := cgroupz.New(j.id, cgroupMount, j.spec.Limits)
 	if err != nil {
 		return fmt.Errorf("cgroupz.New: %w", err)
 	}
 	j.cleanup = append(j.cleanup, cgroup)
+	j.cgroup = cgroup
 
+	var args []string
+	if j.spec.TTY {
+		args = append(args, "-tty")
+	}
+	if cred := j.spec.Credential; cred != nil {
+		// the utility needs to stay privileged to join the cgroup, so it drops privileges for the command itself
+		args = append(args, "-uid", strconv.FormatUint(uint64(cred.UID), 10), "-gid", strconv.FormatUint(uint64(cred.GID), 10))
+	}
+	args = append(args, "-pid-fd", "3", cgroup.Path)
 	j.cmd = exec.CommandContext(
 		j.ctx,
 		"/home/vagrant/bin/utility/cmd", // hard coded path to utility,
-		append([]string{cgroup.Path}, j.command...)...,
+		append(args, j.spec.Command...)...,
 	)
 	j.cmd.SysProcAttr = &syscall.SysProcAttr{
 		Pdeathsig: syscall.SIGKILL,
 	}
-	j.Status = StatusRunning
-
-	j.stdout, err = j.cmd.StdoutPipe()
+	// the utility writes the pid of the command to the pipe, which it gets as fd 3
+	pidReader, pidWriter, err := os.Pipe()
 	if err != nil {
-		return fmt.Errorf("j.cmd.StdoutPipe: %w", err)
+		return fmt.Errorf("os.Pipe: %w", err)
 	}
-	j.stderr, err = j.cmd.StderrPipe()
-	if err != nil {
-		return fmt.Errorf("j.cmd.StderrPipe: %w", err)
+	j.cleanup = append(j.cleanup, pidReader)
+	defer pidWriter.Close()
+	j.cmd.ExtraFiles = []*os.File{pidWriter}
+	// the utility passes its environment and working directory on to the command
+	j.cmd.Env = commandEnv(j.spec.Env)
+	j.cmd.Dir = j.spec.Dir
+
+	if j.spec.TTY {
+		master, slave, err := ptyz.Open()
+		if err != nil {
+			return fmt.Errorf("ptyz.Open: %w", err)
+		}
+		j.cleanup = append(j.cleanup, master)
+		// the child holds its own copy of the slave once started
+		defer slave.Close()
+		if err := ptyz.Setsize(master, defaultRows, defaultCols); err != nil {
+			return fmt.Errorf("ptyz.Setsize: %w", err)
+		}
+
+		j.cmd.Stdin = slave
+		j.cmd.Stdout = slave
+		j.cmd.Stderr = slave
+		j.stdout = ttyReader{master}
+		j.stdinMu.Lock()
+		j.tty = master
+		j.stdin = master
+		j.stdinMu.Unlock()
+	} else {
+		j.stdout, err = j.cmd.StdoutPipe()
+		if err != nil {
+			return fmt.Errorf("j.cmd.StdoutPipe: %w", err)
+		}
+		j.stderr, err = j.cmd.StderrPipe()
+		if err != nil {
+			return fmt.Errorf("j.cmd.StderrPipe: %w", err)
+		}
+		if j.spec.Stdin {
+			stdin, err := j.cmd.StdinPipe()
+			if err != nil {
+				return fmt.Errorf("j.cmd.StdinPipe: %w", err)
+			}
+			j.stdinMu.Lock()
+			j.stdin = stdin
+			j.stdinMu.Unlock()
+		}
 	}
 
 	if err := j.cmd.Start(); err != nil {
 		return fmt.Errorf("j.cmd.Start: %w", err)
 	}
+	j.mu.Lock()
+	j.Status = StatusRunning
+	j.startedAt = time.Now()
+	j.mu.Unlock()
+	// the utility holds its own copy of the writer, closing ours lets the read end once the utility has written
+	pidWriter.Close()
+	go j.readPid(pidReader)
+
+	// a job exceeding its output limit with OutputKill is killed
+	var stdoutExceeded, stderrExceeded <-chan struct{}
+	if j.spec.OutputLimit > 0 && j.spec.OutputPolicy == OutputKill {
+		stdoutExceeded = j.output.Exceeded()
+		stderrExceeded = j.errOutput.Exceeded()
+	}
+
+	// cancelling ctx kills the utility, the rest of the process tree is killed through the cgroup
+	go func() {
+		select {
+		case <-j.ctx.Done():
+		case <-stdoutExceeded:
+			j.markOutputLimited()
+		case <-stderrExceeded:
+			j.markOutputLimited()
+		case <-j.done:
+			return
+		}
+		if err := j.Kill(); err != nil {
+			fmt.Printf("kill job %s: %v\n", j.id, err)
+		}
+	}()
+
+	// the watch ends once the job has ended or its cgroup is removed
+	watchCtx, stopWatch := context.WithCancel(context.Background())
+	go func() {
+		<-j.done
+		stopWatch()
+	}()
+	go func() {
+		// cgroups without the memory controller have no memory.events
+		if err := cgroup.WatchMemoryEvents(watchCtx, j.setMemoryEvents); err != nil && !errors.Is(err, os.ErrNotExist) {
+			fmt.Printf("watch memory events of job %s: %v\n", j.id, err)
+		}
+	}()
 
 	j.goroutines = []func() error{j.stdoutFn, j.stderrFn}
 	j.errch = make(chan error, len(j.goroutines))
@@ -133,9 +492,33 @@ func (j *Job) start() error {
 	return nil
 }
 
+func (j *Job) setMemoryEvents(events cgroupz.MemoryEvents) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.memoryEvents = events
+}
+
+func (j *Job) markOutputLimited() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.outputLimited = true
+}
+
+// commandEnv returns env with PATH set to DefaultPath if env does not set it
+func commandEnv(env []string) []string {
+	for _, kv := range env {
+		if strings.HasPrefix(kv, "PATH=") {
+			return env
+		}
+	}
+	return append(append([]string{}, env...), "PATH="+DefaultPath)
+}
+
 // Wait blocks until the job completes and afterwards, will make available the
-// Status, exit code, and any Errs from stderr that may have written.
+// Status and exit code. Processes the command left in the job's cgroup are killed
+// and Wait returns once they have exited.
 func (j *Job) Wait() error {
+	defer close(j.done)
 	defer j.close()
 
 	var errs error
@@ -148,21 +531,45 @@ func (j *Job) Wait() error {
 		}
 	}
 
-	if err := j.cmd.Wait(); err != nil {
+	err := j.cmd.Wait()
+	// the cgroup still exists, so the counters are read once more in case the watch has not caught up
+	events, eventsErr := j.cgroup.MemoryEvents()
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.endedAt = time.Now()
+	if err != nil {
 		if _, ok := err.(*exec.ExitError); !ok {
 			j.Status = StatusUnknown
 			return fmt.Errorf("j.cmd.Wait: %w", err)
 		}
 	}
 
+	j.exitCode = j.cmd.ProcessState.ExitCode()
 	waitStatus := j.cmd.ProcessState.Sys().(syscall.WaitStatus)
 	if waitStatus.Signaled() {
 		j.Status = StatusStopped
+		j.signal = waitStatus.Signal()
 	} else if waitStatus.Exited() {
 		j.Status = StatusExited
 	} else {
 		j.Status = StatusUnknown
 	}
+	if eventsErr == nil {
+		j.memoryEvents = events
+	}
+	if j.Status == StatusStopped && j.signal == syscall.SIGKILL && j.memoryEvents.OOMKill > 0 && !j.killed {
+		j.Status = StatusOOMKilled
+	}
+	if j.outputLimited {
+		j.Status = StatusOutputLimit
+	}
+	if j.preempted {
+		j.Status = StatusPreempted
+	}
+	if j.timedOut {
+		j.Status = StatusTimedOut
+	}
 
 	if errs != nil {
 		return fmt.Errorf("error from goroutine: %+v", errs)
@@ -177,19 +584,316 @@ func (j *Job) Wait() error {
 // the reader gets to the end of the internal buffer and blocks until new writes are made or when the writer is closed.
 // Stream blocks until the command closes.
 func (j *Job) Stream(ctx context.Context, writer io.Writer) error {
-	if _, err := io.Copy(writer, j.getReaderFn(ctx)); err != nil {
+	if _, err := io.Copy(writer, j.output.GetReader(ctx)); err != nil {
 		return fmt.Errorf("io.Copy: %w", err)
 	}
 	return nil
 }
 
+// StreamAt streams the output selected by opts.Source starting at the position described by opts. Each chunk is
+// written with its byte offset in that output, which callers can use as StreamOptions.Offset to resume streaming later.
+// If opts.Follow is false, StreamAt returns once the output written at the time of the call has been streamed.
+func (j *Job) StreamAt(ctx context.Context, writer io.WriterAt, opts StreamOptions) error {
+	var output *bufferz.MultiReader
+	switch opts.Source {
+	case SourceStdout, "":
+		output = j.output
+	case SourceStderr:
+		output = j.errOutput
+	default:
+		return fmt.Errorf("unknown source %q", opts.Source)
+	}
+
+	offset := opts.Offset
+	if opts.TailBytes > 0 || opts.TailLines > 0 {
+		offset = 0
+		if opts.TailBytes > 0 {
+			offset = output.Len() - opts.TailBytes
+		}
+		if opts.TailLines > 0 {
+			if lineOffset := output.LineOffset(opts.TailLines); lineOffset > offset {
+				offset = lineOffset
+			}
+		}
+		if offset < 0 {
+			offset = 0
+		}
+	}
+
+	reader := output.GetReaderAt(ctx, offset, opts.Follow)
+	truncatedWriter, _ := writer.(TruncatedWriter)
+	buf := make([]byte, 32*1024)
+	for {
+		skipped := reader.Skipped()
+		n, err := reader.Read(buf)
+		if skipped := reader.Skipped() - skipped; skipped > 0 {
+			offset += skipped
+			if truncatedWriter != nil {
+				if terr := truncatedWriter.Truncated(skipped); terr != nil {
+					return fmt.Errorf("writer.Truncated: %w", terr)
+				}
+			}
+		}
+		if n > 0 {
+			if _, werr := writer.WriteAt(buf[:n], offset); werr != nil {
+				return fmt.Errorf("writer.WriteAt: %w", werr)
+			}
+			offset += int64(n)
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("reader.Read: %w", err)
+		}
+	}
+}
+
+// WriteStdin writes p to the stdin of the command. It is safe to call concurrently, but writes from
+// different callers are not ordered. The job must have been created with Spec.Stdin.
+func (j *Job) WriteStdin(p []byte) (int, error) {
+	j.stdinMu.Lock()
+	defer j.stdinMu.Unlock()
+	if j.stdin == nil {
+		return 0, ErrNoStdin
+	}
+	return j.stdin.Write(p)
+}
+
+// CloseStdin closes the stdin of the command, which then reads EOF. Closing an already closed stdin is a no-op.
+// With a TTY, the terminal stays open and the end-of-file character is sent instead, which is read as EOF
+// by commands reading lines from the terminal.
+func (j *Job) CloseStdin() error {
+	j.stdinMu.Lock()
+	defer j.stdinMu.Unlock()
+	if j.stdin == nil {
+		return ErrNoStdin
+	}
+	if j.tty != nil {
+		_, err := j.tty.Write([]byte{ctrlD})
+		return err
+	}
+	if j.stdinClosed {
+		return nil
+	}
+	j.stdinClosed = true
+	return j.stdin.Close()
+}
+
+// HasStdin reports whether the command's stdin can be written to.
+func (j *Job) HasStdin() bool {
+	return j.spec.Stdin || j.spec.TTY
+}
+
+// HasTTY reports whether the command runs under a pseudo-terminal.
+func (j *Job) HasTTY() bool {
+	return j.spec.TTY
+}
+
+// Resize sets the window size of the job's terminal.
+func (j *Job) Resize(rows, cols uint16) error {
+	j.stdinMu.Lock()
+	defer j.stdinMu.Unlock()
+	if j.tty == nil {
+		return ErrNoTTY
+	}
+	return ptyz.Setsize(j.tty, rows, cols)
+}
+
+// Signal sends sig to the command. Processes the command started do not receive it unless the command passes it on.
+func (j *Job) Signal(sig syscall.Signal) error {
+	select {
+	case <-j.done:
+		return ErrNotRunning
+	default:
+	}
+	if j.cmd == nil || j.cmd.Process == nil {
+		return ErrNotRunning
+	}
+	// the utility forwards signals it can catch, which covers the time before the pid of the command is known
+	pid := j.cmd.Process.Pid
+	j.mu.Lock()
+	if j.pid != 0 {
+		pid = j.pid
+	}
+	j.mu.Unlock()
+	if err := syscall.Kill(pid, sig); err != nil {
+		if errors.Is(err, syscall.ESRCH) {
+			return ErrNotRunning
+		}
+		return fmt.Errorf("kill: %w", err)
+	}
+	return nil
+}
+
+// SignalAll sends sig to every process of the job
+func (j *Job) SignalAll(sig syscall.Signal) error {
+	select {
+	case <-j.done:
+		return ErrNotRunning
+	default:
+	}
+	if j.cgroup == nil || j.cmd == nil || j.cmd.Process == nil {
+		return ErrNotRunning
+	}
+	pids, err := j.cgroup.Procs()
+	if err != nil {
+		return fmt.Errorf("cgroup.Procs: %w", err)
+	}
+	var errs error
+	for _, pid := range pids {
+		// the utility would forward sig to the command a second time
+		if pid == j.cmd.Process.Pid {
+			continue
+		}
+		if err := syscall.Kill(pid, sig); err != nil && !errors.Is(err, syscall.ESRCH) {
+			errs = multierr.Append(errs, fmt.Errorf("kill %d: %w", pid, err))
+		}
+	}
+	// the command itself is signalled even if it has not joined the cgroup listing yet
+	if err := j.Signal(sig); err != nil && !errors.Is(err, ErrNotRunning) {
+		errs = multierr.Append(errs, err)
+	}
+	return errs
+}
+
+// Kill sends SIGKILL to every process of the job, which can not be caught
+func (j *Job) Kill() error {
+	if j.cgroup == nil || j.cmd == nil || j.cmd.Process == nil {
+		return ErrNotRunning
+	}
+	j.mu.Lock()
+	j.killed = true
+	j.mu.Unlock()
+	var errs error
+	if err := j.cgroup.Kill(); err != nil {
+		errs = multierr.Append(errs, fmt.Errorf("cgroup.Kill: %w", err))
+	}
+	// the command is killed along with the utility through its parent death signal, even if killing
+	// the cgroup failed
+	if err := j.cmd.Process.Kill(); err != nil && !errors.Is(err, os.ErrProcessDone) {
+		errs = multierr.Append(errs, fmt.Errorf("kill: %w", err))
+	}
+	if errs != nil {
+		// the cgroup is removed once the job has ended, which can happen while it is being killed
+		select {
+		case <-j.done:
+			return nil
+		default:
+		}
+	}
+	return errs
+}
+
+// Preempt stops the job like Stop, after which it ends with StatusPreempted however the command exits
+func (j *Job) Preempt(sig syscall.Signal, grace time.Duration) error {
+	j.mu.Lock()
+	j.preempted = true
+	j.mu.Unlock()
+	return j.Stop(sig, grace)
+}
+
+// Timeout stops the job like Stop, after which it ends with StatusTimedOut however the command exits
+func (j *Job) Timeout(sig syscall.Signal, grace time.Duration) error {
+	j.mu.Lock()
+	j.timedOut = true
+	j.mu.Unlock()
+	return j.Stop(sig, grace)
+}
+
+// Stop sends sig to the command and kills every process of the job if the job has not ended after grace.
+// Stop returns once the job has ended, which requires Wait to be running.
+func (j *Job) Stop(sig syscall.Signal, grace time.Duration) error {
+	if err := j.Signal(sig); err != nil {
+		if errors.Is(err, ErrNotRunning) {
+			<-j.done
+			return nil
+		}
+		return err
+	}
+	timer := time.NewTimer(grace)
+	defer timer.Stop()
+	select {
+	case <-j.done:
+		return nil
+	case <-timer.C:
+	}
+	if err := j.Kill(); err != nil {
+		fmt.Printf("kill job %s: %v\n", j.id, err)
+	}
+	<-j.done
+	return nil
+}
+
+// OutputSize returns the number of bytes of stdout and stderr the job keeps
+func (j *Job) OutputSize() int64 {
+	return j.output.Len() - j.output.Start() + j.errOutput.Len() - j.errOutput.Start()
+}
+
+// Spec returns the spec the Job was created with
+func (j *Job) Spec() Spec {
+	return j.spec
+}
+
+// Done returns a channel that is closed once the job has ended, every process of the job has exited
+// and its final State is available
+func (j *Job) Done() <-chan struct{} {
+	return j.done
+}
+
 // Result returns the programs exit code and status. This is valid only after Wait is called and the program finishes
 // otherwise it will return -1 and StatusUnknown
 func (j *Job) Result() (int, Status) {
-	if j.cmd == nil || j.cmd.ProcessState == nil {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.result()
+}
+
+func (j *Job) result() (int, Status) {
+	if j.endedAt.IsZero() {
 		return -1, StatusUnknown
 	}
-	return j.cmd.ProcessState.ExitCode(), j.Status
+	return j.exitCode, j.Status
+}
+
+// State returns a snapshot of the Job's status, exit code and timing. It is safe to call concurrently
+// with Start and Wait. While the job is running, the exit code is -1 and EndedAt is the zero time.
+func (j *Job) State() State {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return State{
+		Status:    j.Status,
+		ExitCode:  j.exitCode,
+		Signal:    j.signal,
+		StartedAt: j.startedAt,
+		EndedAt:   j.endedAt,
+
+		OutputTruncated: j.outputTruncated + j.output.Truncated() + j.errOutput.Truncated(),
+		Usage:           j.usage,
+		MemoryEvents:    j.memoryEvents,
+	}
+}
+
+// Stats returns the resource usage of the running job, read from its cgroup. Once the job has ended only the
+// summary in State.Usage is kept.
+func (j *Job) Stats() (cgroupz.Stats, error) {
+	select {
+	case <-j.done:
+		return cgroupz.Stats{}, ErrNotRunning
+	default:
+	}
+	if j.cgroup == nil || j.cmd == nil || j.cmd.Process == nil {
+		return cgroupz.Stats{}, ErrNotRunning
+	}
+	stats, err := j.cgroup.Stats()
+	if err != nil {
+		// the cgroup is removed once the job has ended, which can happen while it is being read
+		if errors.Is(err, os.ErrNotExist) {
+			return cgroupz.Stats{}, ErrNotRunning
+		}
+		return cgroupz.Stats{}, fmt.Errorf("cgroup.Stats: %w", err)
+	}
+	return stats, nil
 }
 
 // convenience method to access Cmd for local testing
@@ -198,19 +902,24 @@ func (j *Job) Cmd() *exec.Cmd {
 }
 
 func (j *Job) stderrFn() error {
-	var errBuf bytes.Buffer
-	if _, err := io.Copy(&errBuf, j.stderr); err != nil {
+	// with a tty, stderr is written to the terminal and there is nothing to copy
+	if j.stderr == nil {
+		return j.errOutput.Close()
+	}
+	if _, err := io.Copy(j.errOutput, j.stderr); err != nil {
 		return fmt.Errorf("stderr.Copy: %w", err)
 	}
-	j.Err = errBuf.String()
+	if err := j.errOutput.Close(); err != nil {
+		return fmt.Errorf("wc.Close: %w", err)
+	}
 	return nil
 }
 
 func (j *Job) stdoutFn() error {
-	if _, err := io.Copy(j.writeCloser, j.stdout); err != nil {
+	if _, err := io.Copy(j.output, j.stdout); err != nil {
 		return fmt.Errorf("stdout.Copy: %w", err)
 	}
-	if err := j.writeCloser.Close(); err != nil {
+	if err := j.output.Close(); err != nil {
 		return fmt.Errorf("wc.Close: %w", err)
 	}
 	return nil
@@ -218,6 +927,20 @@ func (j *Job) stdoutFn() error {
 
 func (j *Job) close() error {
 	var errs error
+	if j.cgroup != nil {
+		if err := j.killRemaining(); err != nil {
+			errs = multierr.Append(errs, err)
+		}
+		// the usage is only accounted while the cgroup exists
+		stats, err := j.cgroup.Stats()
+		if err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("cgroup.Stats: %w", err))
+		} else {
+			j.mu.Lock()
+			j.usage = usageOf(stats)
+			j.mu.Unlock()
+		}
+	}
 	for _, closer := range j.cleanup {
 		if err := closer.Close(); err != nil {
 			errs = multierr.Append(errs, err)
@@ -225,3 +948,57 @@ func (j *Job) close() error {
 	}
 	return errs
 }
+
+// killRemaining kills the processes left in the cgroup once the command has exited, such as processes it started
+// that redirected their stdio, and waits for them to exit so the cgroup can be removed
+func (j *Job) killRemaining() error {
+	populated, err := j.cgroup.Populated()
+	if err != nil || !populated {
+		return err
+	}
+	var errs error
+	if err := j.cgroup.Kill(); err != nil {
+		// killed processes may still be exiting, so the cgroup is waited on anyway
+		errs = multierr.Append(errs, fmt.Errorf("cgroup.Kill: %w", err))
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), killTimeout)
+	defer cancel()
+	if err := j.cgroup.WaitEmpty(ctx); err != nil {
+		errs = multierr.Append(errs, fmt.Errorf("cgroup.WaitEmpty: %w", err))
+	}
+	return errs
+}
+
+// readPid reads the pid of the command the utility writes to r once it has started the command.
+// r is empty if the utility exits before starting it.
+func (j *Job) readPid(r io.Reader) {
+	data, err := io.ReadAll(r)
+	if err != nil || len(data) == 0 {
+		return
+	}
+	pid, err := strconv.Atoi(string(data))
+	if err != nil {
+		fmt.Printf("pid of job %s: %v\n", j.id, err)
+		return
+	}
+	j.mu.Lock()
+	j.pid = pid
+	j.mu.Unlock()
+}
+
+// the end-of-file character of a terminal in canonical mode
+const ctrlD = 0x04
+
+// ttyReader reads from the master end of a terminal. Once every slave end is closed, which happens when
+// the command and its children exit, reads fail with EIO, which ttyReader reports as io.EOF.
+type ttyReader struct {
+	master *os.File
+}
+
+func (t ttyReader) Read(p []byte) (int, error) {
+	n, err := t.master.Read(p)
+	if errors.Is(err, syscall.EIO) {
+		return n, io.EOF
+	}
+	return n, err
+}
@@ -3,10 +3,17 @@ package jobs
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"io/ioutil"
 	"job_runner/pkg/cgroupz"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
 	"sync"
+	"syscall"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -14,7 +21,7 @@ import (
 // these tests must be run in a linux vm
 
 func Test_Job_SimpleStartAndStream(t *testing.T) {
-	job := New(context.Background(), []string{"echo", "hello"}, cgroupz.ResourceLimit{CpuWeight: 50, MaxMem: 1e8})
+	job := New(context.Background(), Spec{Command: []string{"echo", "hello"}, Limits: cgroupz.ResourceLimit{CpuWeight: 50, MaxMem: 1e8}})
 	err := job.Start()
 	require.NoError(t, err)
 
@@ -26,7 +33,7 @@ func Test_Job_SimpleStartAndStream(t *testing.T) {
 	require.Equal(t, StatusExited, status)
 
 	var buf bytes.Buffer
-	err = job.Stream(&buf)
+	err = job.Stream(context.Background(), &buf)
 	require.NoError(t, err)
 	// echo will append a newline
 	require.Equal(t, "hello\n", buf.String())
@@ -34,7 +41,7 @@ func Test_Job_SimpleStartAndStream(t *testing.T) {
 
 func Test_JobStop(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
-	job := New(ctx, []string{"sleep", "5"}, cgroupz.ResourceLimit{CpuWeight: 50, MaxMem: 1e8})
+	job := New(ctx, Spec{Command: []string{"sleep", "5"}, Limits: cgroupz.ResourceLimit{CpuWeight: 50, MaxMem: 1e8}})
 	err := job.Start()
 	require.NoError(t, err)
 
@@ -50,7 +57,7 @@ func Test_JobStop(t *testing.T) {
 func Test_Job_MultipleStreamers(t *testing.T) {
 	// useful if -race flag is used
 	cmd := []string{"sh", "-c", "for i in {1..50}; do echo ${RANDOM}; sleep 0.05; done"}
-	job := New(context.Background(), cmd, cgroupz.ResourceLimit{CpuWeight: 50, MaxMem: 1e8})
+	job := New(context.Background(), Spec{Command: cmd, Limits: cgroupz.ResourceLimit{CpuWeight: 50, MaxMem: 1e8}})
 
 	var wg sync.WaitGroup
 	n := 20
@@ -58,7 +65,7 @@ func Test_Job_MultipleStreamers(t *testing.T) {
 	for i := 0; i < n; i++ {
 		go func() {
 			defer wg.Done()
-			err := job.Stream(ioutil.Discard)
+			err := job.Stream(context.Background(), ioutil.Discard)
 			require.NoError(t, err)
 		}()
 	}
@@ -69,3 +76,370 @@ func Test_Job_MultipleStreamers(t *testing.T) {
 	require.NoError(t, err)
 	wg.Wait()
 }
+
+func Test_Job_WriteStdin(t *testing.T) {
+	job := New(context.Background(), Spec{Command: []string{"cat"}, Limits: cgroupz.ResourceLimit{CpuWeight: 50, MaxMem: 1e8}, Stdin: true})
+	err := job.Start()
+	require.NoError(t, err)
+
+	_, err = job.WriteStdin([]byte("hello\n"))
+	require.NoError(t, err)
+	require.NoError(t, job.CloseStdin())
+
+	err = job.Wait()
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	err = job.Stream(context.Background(), &buf)
+	require.NoError(t, err)
+	require.Equal(t, "hello\n", buf.String())
+}
+
+func Test_Job_TTY(t *testing.T) {
+	job := New(context.Background(), Spec{Command: []string{"tty"}, Limits: cgroupz.ResourceLimit{CpuWeight: 50, MaxMem: 1e8}, TTY: true})
+	err := job.Start()
+	require.NoError(t, err)
+	err = job.Wait()
+	require.NoError(t, err)
+
+	code, _ := job.Result()
+	require.Equal(t, 0, code)
+
+	var buf bytes.Buffer
+	err = job.Stream(context.Background(), &buf)
+	require.NoError(t, err)
+	require.Contains(t, buf.String(), "/dev/pts/")
+}
+
+func Test_Job_EnvAndDir(t *testing.T) {
+	dir := t.TempDir()
+	job := New(context.Background(), Spec{
+		Command: []string{"sh", "-c", "env | sort; pwd"},
+		Limits:  cgroupz.ResourceLimit{CpuWeight: 50, MaxMem: 1e8},
+		Env:     []string{"FOO=bar"},
+		Dir:     dir,
+	})
+	require.NoError(t, job.Start())
+	require.NoError(t, job.Wait())
+
+	var buf bytes.Buffer
+	require.NoError(t, job.Stream(context.Background(), &buf))
+	require.Contains(t, buf.String(), "FOO=bar\n")
+	require.Contains(t, buf.String(), "PATH="+DefaultPath+"\n")
+	require.NotContains(t, buf.String(), "HOME=")
+	require.True(t, strings.HasSuffix(buf.String(), dir+"\n"))
+}
+
+func Test_Job_Credential(t *testing.T) {
+	job := New(context.Background(), Spec{
+		Command:    []string{"sh", "-c", "id -u; id -g"},
+		Limits:     cgroupz.ResourceLimit{CpuWeight: 50, MaxMem: 1e8},
+		Credential: &Credential{UID: 65534, GID: 65534},
+	})
+	require.NoError(t, job.Start())
+	require.NoError(t, job.Wait())
+
+	var buf bytes.Buffer
+	require.NoError(t, job.Stream(context.Background(), &buf))
+	require.Equal(t, "65534\n65534\n", buf.String())
+}
+
+func Test_Job_StoppedBySignal(t *testing.T) {
+	job := New(context.Background(), Spec{Command: []string{"sh", "-c", "kill -TERM $$"}, Limits: cgroupz.ResourceLimit{CpuWeight: 50, MaxMem: 1e8}})
+	require.NoError(t, job.Start())
+	require.NoError(t, job.Wait())
+
+	<-job.Done()
+	state := job.State()
+	require.Equal(t, StatusStopped, state.Status)
+	require.Equal(t, syscall.SIGTERM, state.Signal)
+}
+
+func Test_Job_StopGraceful(t *testing.T) {
+	job := New(context.Background(), Spec{Command: []string{"sh", "-c", "trap 'exit 7' TERM; while :; do sleep 0.1; done"}, Limits: cgroupz.ResourceLimit{CpuWeight: 50, MaxMem: 1e8}})
+	require.NoError(t, job.Start())
+	waitErr := make(chan error, 1)
+	go func() { waitErr <- job.Wait() }()
+
+	// give the shell time to install its trap
+	time.Sleep(200 * time.Millisecond)
+	require.NoError(t, job.Stop(syscall.SIGTERM, 5*time.Second))
+	require.NoError(t, <-waitErr)
+
+	code, status := job.Result()
+	require.Equal(t, StatusExited, status)
+	require.Equal(t, 7, code)
+}
+
+func Test_Job_StopEscalatesToKill(t *testing.T) {
+	job := New(context.Background(), Spec{Command: []string{"sh", "-c", "trap '' TERM; while :; do :; done"}, Limits: cgroupz.ResourceLimit{CpuWeight: 50, MaxMem: 1e8}})
+	require.NoError(t, job.Start())
+	waitErr := make(chan error, 1)
+	go func() { waitErr <- job.Wait() }()
+
+	time.Sleep(200 * time.Millisecond)
+	start := time.Now()
+	require.NoError(t, job.Stop(syscall.SIGTERM, 300*time.Millisecond))
+	require.GreaterOrEqual(t, time.Since(start), 300*time.Millisecond)
+	require.NoError(t, <-waitErr)
+
+	state := job.State()
+	require.Equal(t, StatusStopped, state.Status)
+	require.Equal(t, syscall.SIGKILL, state.Signal)
+}
+
+func Test_Job_SignalReachesCommand(t *testing.T) {
+	job := New(context.Background(), Spec{Command: []string{"sh", "-c", "trap 'echo hup' HUP; while :; do sleep 0.1; done"}, Limits: cgroupz.ResourceLimit{CpuWeight: 50, MaxMem: 1e8}})
+	require.NoError(t, job.Start())
+	waitErr := make(chan error, 1)
+	go func() { waitErr <- job.Wait() }()
+
+	time.Sleep(200 * time.Millisecond)
+	require.NoError(t, job.Signal(syscall.SIGHUP))
+	// SIGSTOP can not be forwarded by the utility, it has to reach the command directly
+	require.NoError(t, job.Signal(syscall.SIGSTOP))
+	require.NoError(t, job.Signal(syscall.SIGCONT))
+	// the shell runs the trap once its current sleep returns
+	time.Sleep(300 * time.Millisecond)
+	require.NoError(t, job.Signal(syscall.SIGKILL))
+	require.NoError(t, <-waitErr)
+
+	var buf bytes.Buffer
+	require.NoError(t, job.Stream(context.Background(), &buf))
+	require.Equal(t, "hup\n", buf.String())
+	state := job.State()
+	require.Equal(t, StatusStopped, state.Status)
+	require.Equal(t, syscall.SIGKILL, state.Signal)
+	require.ErrorIs(t, job.Signal(syscall.SIGTERM), ErrNotRunning)
+}
+
+func Test_Job_CommandPid(t *testing.T) {
+	// the command does not inherit the fd the utility reports its pid on
+	job := New(context.Background(), Spec{Command: []string{"sh", "-c", "echo $$; [ -e /proc/$$/fd/3 ] && echo fd 3; exec cat"}, Limits: cgroupz.ResourceLimit{CpuWeight: 50, MaxMem: 1e8}, Stdin: true})
+	require.NoError(t, job.Start())
+	waitErr := make(chan error, 1)
+	go func() { waitErr <- job.Wait() }()
+
+	var pid int
+	require.Eventually(t, func() bool {
+		job.mu.Lock()
+		defer job.mu.Unlock()
+		pid = job.pid
+		return pid != 0
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NotEqual(t, job.cmd.Process.Pid, pid)
+	require.NoError(t, job.CloseStdin())
+	require.NoError(t, <-waitErr)
+
+	var buf bytes.Buffer
+	require.NoError(t, job.Stream(context.Background(), &buf))
+	require.Equal(t, fmt.Sprintf("%d\n", pid), buf.String())
+}
+
+func Test_Job_Restore(t *testing.T) {
+	ended := time.Now()
+	job := Restore(Spec{Command: []string{"true"}, TTY: true}, State{Status: StatusExited, ExitCode: 3, StartedAt: ended.Add(-time.Second), EndedAt: ended})
+
+	select {
+	case <-job.Done():
+	default:
+		t.Fatal("restored job has not ended")
+	}
+	code, status := job.Result()
+	require.Equal(t, 3, code)
+	require.Equal(t, StatusExited, status)
+	require.True(t, job.HasTTY())
+	require.ErrorIs(t, job.Signal(syscall.SIGTERM), ErrNotRunning)
+
+	var buf bytes.Buffer
+	require.NoError(t, job.Stream(context.Background(), &buf))
+	require.Empty(t, buf.String())
+}
+
+func Test_Job_OutputDir(t *testing.T) {
+	dir := t.TempDir()
+	spec := Spec{Command: []string{"sh", "-c", "echo out; echo err >&2"}, Limits: cgroupz.ResourceLimit{CpuWeight: 50, MaxMem: 1e8}, OutputDir: dir}
+	job := New(context.Background(), spec)
+	require.NoError(t, job.Start())
+	require.NoError(t, job.Wait())
+
+	// a restored job reads the output the previous job stored
+	restored := Restore(spec, job.State())
+	var out bytes.Buffer
+	require.NoError(t, restored.Stream(context.Background(), &out))
+	require.Equal(t, "out\n", out.String())
+	require.DirExists(t, filepath.Join(dir, string(SourceStderr)))
+}
+
+func Test_Job_OutputLimitKill(t *testing.T) {
+	job := New(context.Background(), Spec{
+		Command:      []string{"sh", "-c", "while true; do echo spam; done"},
+		Limits:       cgroupz.ResourceLimit{CpuWeight: 50, MaxMem: 1e8},
+		OutputLimit:  1024,
+		OutputPolicy: OutputKill,
+	})
+	require.NoError(t, job.Start())
+	require.NoError(t, job.Wait())
+
+	state := job.State()
+	require.Equal(t, StatusOutputLimit, state.Status)
+	require.Equal(t, syscall.SIGKILL, state.Signal)
+	require.Greater(t, state.OutputTruncated, int64(0))
+	var out bytes.Buffer
+	require.NoError(t, job.Stream(context.Background(), &out))
+	require.Equal(t, 1024, out.Len())
+}
+
+func Test_Job_OutputLimitRing(t *testing.T) {
+	job := New(context.Background(), Spec{
+		Command:     []string{"seq", "1000"},
+		Limits:      cgroupz.ResourceLimit{CpuWeight: 50, MaxMem: 1e8},
+		OutputLimit: 9,
+	})
+	require.NoError(t, job.Start())
+	require.NoError(t, job.Wait())
+
+	require.Equal(t, StatusExited, job.State().Status)
+	var out bytes.Buffer
+	require.NoError(t, job.Stream(context.Background(), &out))
+	require.Equal(t, "999\n1000\n", out.String())
+}
+
+func Test_Job_Cancel(t *testing.T) {
+	job := New(context.Background(), Spec{Command: []string{"true"}, Limits: cgroupz.ResourceLimit{CpuWeight: 50, MaxMem: 1e8}})
+	require.ErrorIs(t, job.Cancel(), ErrNotQueued)
+
+	job.Queue()
+	require.Equal(t, StatusQueued, job.State().Status)
+	require.NoError(t, job.Cancel())
+	<-job.Done()
+	state := job.State()
+	require.Equal(t, StatusCancelled, state.Status)
+	require.True(t, state.StartedAt.IsZero())
+	require.False(t, state.EndedAt.IsZero())
+	require.ErrorIs(t, job.Cancel(), ErrNotQueued)
+}
+
+func Test_Job_Preempt(t *testing.T) {
+	job := New(context.Background(), Spec{Command: []string{"sleep", "10"}, Limits: cgroupz.ResourceLimit{CpuWeight: 50, MaxMem: 1e8}})
+	require.NoError(t, job.Start())
+	waitErr := make(chan error, 1)
+	go func() { waitErr <- job.Wait() }()
+
+	require.NoError(t, job.Preempt(syscall.SIGTERM, 5*time.Second))
+	require.NoError(t, <-waitErr)
+
+	state := job.State()
+	require.Equal(t, StatusPreempted, state.Status)
+	require.Equal(t, syscall.SIGTERM, state.Signal)
+}
+
+func Test_Job_Timeout(t *testing.T) {
+	job := New(context.Background(), Spec{Command: []string{"sleep", "10"}, Limits: cgroupz.ResourceLimit{CpuWeight: 50, MaxMem: 1e8}})
+	require.NoError(t, job.Start())
+	waitErr := make(chan error, 1)
+	go func() { waitErr <- job.Wait() }()
+
+	require.NoError(t, job.Timeout(syscall.SIGTERM, 5*time.Second))
+	require.NoError(t, <-waitErr)
+
+	state := job.State()
+	require.Equal(t, StatusTimedOut, state.Status)
+	require.Equal(t, syscall.SIGTERM, state.Signal)
+}
+
+func Test_Job_Stats(t *testing.T) {
+	job := New(context.Background(), Spec{Command: []string{"sleep", "10"}, Limits: cgroupz.ResourceLimit{CpuWeight: 50, MaxMem: 1e8}})
+	_, err := job.Stats()
+	require.ErrorIs(t, err, ErrNotRunning)
+
+	require.NoError(t, job.Start())
+	waitErr := make(chan error, 1)
+	go func() { waitErr <- job.Wait() }()
+
+	_, err = job.Stats()
+	require.NoError(t, err)
+
+	require.NoError(t, job.Stop(syscall.SIGKILL, time.Second))
+	require.NoError(t, <-waitErr)
+	_, err = job.Stats()
+	require.ErrorIs(t, err, ErrNotRunning)
+}
+
+func Test_Job_OOMKilled(t *testing.T) {
+	job := New(context.Background(), Spec{Command: []string{"sleep", "10"}, Limits: cgroupz.ResourceLimit{CpuWeight: 50, MaxMem: 1e8}})
+	require.NoError(t, job.Start())
+	waitErr := make(chan error, 1)
+	go func() { waitErr <- job.Wait() }()
+
+	// stands in for the kernel, which counts the kill in memory.events and kills with SIGKILL
+	events := filepath.Join(job.cgroup.Path, "memory.events")
+	require.NoError(t, os.WriteFile(events, []byte("low 0\nhigh 0\nmax 3\noom 1\noom_kill 1\n"), 0644))
+	require.Eventually(t, func() bool { return job.State().MemoryEvents.OOMKill == 1 }, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, job.Signal(syscall.SIGKILL))
+	require.NoError(t, <-waitErr)
+
+	state := job.State()
+	require.Equal(t, StatusOOMKilled, state.Status)
+	require.Equal(t, syscall.SIGKILL, state.Signal)
+	require.Equal(t, cgroupz.MemoryEvents{Max: 3, OOM: 1, OOMKill: 1}, state.MemoryEvents)
+}
+
+func Test_Job_KilledIsNotOOMKilled(t *testing.T) {
+	job := New(context.Background(), Spec{Command: []string{"sleep", "10"}, Limits: cgroupz.ResourceLimit{CpuWeight: 50, MaxMem: 1e8}})
+	require.NoError(t, job.Start())
+	waitErr := make(chan error, 1)
+	go func() { waitErr <- job.Wait() }()
+
+	// a process of the job was OOM-killed earlier, but the job itself is killed by Kill
+	events := filepath.Join(job.cgroup.Path, "memory.events")
+	require.NoError(t, os.WriteFile(events, []byte("oom_kill 1\n"), 0644))
+	// the command is killed along with the utility even where the processes of the cgroup can not be listed
+	_ = job.Kill()
+	require.NoError(t, <-waitErr)
+
+	state := job.State()
+	require.Equal(t, StatusStopped, state.Status)
+	require.Equal(t, uint64(1), state.MemoryEvents.OOMKill)
+}
+
+func Test_Job_KillsProcessesLeftBehind(t *testing.T) {
+	job := New(context.Background(), Spec{Command: []string{"cat"}, Limits: cgroupz.ResourceLimit{CpuWeight: 50, MaxMem: 1e8}, Stdin: true})
+	require.NoError(t, job.Start())
+	waitErr := make(chan error, 1)
+	go func() { waitErr <- job.Wait() }()
+
+	// stands in for a process the command started in the background, which the kernel lists in the job's cgroup
+	left := exec.Command("sleep", "30")
+	require.NoError(t, left.Start())
+	leftErr := make(chan error, 1)
+	go func() { leftErr <- left.Wait() }()
+	procs := filepath.Join(job.cgroup.Path, "cgroup.procs")
+	// the utility adds itself to the cgroup once it starts
+	require.Eventually(t, func() bool {
+		data, err := os.ReadFile(procs)
+		return err == nil && len(data) > 0
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, os.WriteFile(procs, []byte(fmt.Sprintf("%d\n", left.Process.Pid)), 0644))
+	events := filepath.Join(job.cgroup.Path, "cgroup.events")
+	require.NoError(t, os.WriteFile(events, []byte("populated 1\nfrozen 0\n"), 0644))
+
+	// the command exits, leaving the background process behind, which is killed
+	require.NoError(t, job.CloseStdin())
+	select {
+	case <-leftErr:
+	case <-time.After(5 * time.Second):
+		t.Fatal("process left in the cgroup was not killed")
+	}
+	require.Equal(t, syscall.SIGKILL, left.ProcessState.Sys().(syscall.WaitStatus).Signal())
+
+	// the job ends only once the cgroup is empty
+	select {
+	case <-job.Done():
+		t.Fatal("job ended while its cgroup was populated")
+	case <-time.After(50 * time.Millisecond):
+	}
+	require.NoError(t, os.WriteFile(events, []byte("populated 0\nfrozen 0\n"), 0644))
+	require.NoError(t, <-waitErr)
+	require.Equal(t, StatusExited, job.State().Status)
+}
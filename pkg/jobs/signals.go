@@ -0,0 +1,66 @@
+package jobs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// signals that are commonly sent to or stop a job, by their conventional name
+var signalNames = map[syscall.Signal]string{
+	syscall.SIGABRT:  "SIGABRT",
+	syscall.SIGALRM:  "SIGALRM",
+	syscall.SIGBUS:   "SIGBUS",
+	syscall.SIGCHLD:  "SIGCHLD",
+	syscall.SIGCONT:  "SIGCONT",
+	syscall.SIGFPE:   "SIGFPE",
+	syscall.SIGHUP:   "SIGHUP",
+	syscall.SIGILL:   "SIGILL",
+	syscall.SIGINT:   "SIGINT",
+	syscall.SIGKILL:  "SIGKILL",
+	syscall.SIGPIPE:  "SIGPIPE",
+	syscall.SIGQUIT:  "SIGQUIT",
+	syscall.SIGSEGV:  "SIGSEGV",
+	syscall.SIGSTOP:  "SIGSTOP",
+	syscall.SIGSYS:   "SIGSYS",
+	syscall.SIGTERM:  "SIGTERM",
+	syscall.SIGTRAP:  "SIGTRAP",
+	syscall.SIGTSTP:  "SIGTSTP",
+	syscall.SIGTTIN:  "SIGTTIN",
+	syscall.SIGTTOU:  "SIGTTOU",
+	syscall.SIGURG:   "SIGURG",
+	syscall.SIGUSR1:  "SIGUSR1",
+	syscall.SIGUSR2:  "SIGUSR2",
+	syscall.SIGWINCH: "SIGWINCH",
+	syscall.SIGXCPU:  "SIGXCPU",
+	syscall.SIGXFSZ:  "SIGXFSZ",
+}
+
+// SignalName returns the name of sig such as "SIGTERM", or its number for signals without a name
+func SignalName(sig syscall.Signal) string {
+	if name, ok := signalNames[sig]; ok {
+		return name
+	}
+	return strconv.Itoa(int(sig))
+}
+
+// ParseSignal parses a signal given by name, with or without the SIG prefix and in any case, or by number
+func ParseSignal(s string) (syscall.Signal, error) {
+	if n, err := strconv.Atoi(s); err == nil {
+		if n <= 0 || n > 64 {
+			return 0, fmt.Errorf("invalid signal number %d", n)
+		}
+		return syscall.Signal(n), nil
+	}
+	name := strings.ToUpper(s)
+	if !strings.HasPrefix(name, "SIG") {
+		name = "SIG" + name
+	}
+	for sig, signalName := range signalNames {
+		if name == signalName {
+			return sig, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown signal %q", s)
+}
@@ -0,0 +1,36 @@
+package jobs
+
+import (
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseSignal(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    syscall.Signal
+		wantErr bool
+	}{
+		{in: "SIGTERM", want: syscall.SIGTERM},
+		{in: "term", want: syscall.SIGTERM},
+		{in: "Kill", want: syscall.SIGKILL},
+		{in: "9", want: syscall.SIGKILL},
+		{in: "0", wantErr: true},
+		{in: "SIGNOPE", wantErr: true},
+		{in: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseSignal(tt.in)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+			require.Equal(t, SignalName(got), SignalName(tt.want))
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package ptyz
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Open_MasterReadsSlaveWrites(t *testing.T) {
+	master, slave, err := Open()
+	require.NoError(t, err)
+	defer master.Close()
+	defer slave.Close()
+
+	require.True(t, IsTerminal(slave))
+
+	_, err = slave.Write([]byte("hello"))
+	require.NoError(t, err)
+	buf := make([]byte, 5)
+	n, err := master.Read(buf)
+	require.NoError(t, err)
+	require.Equal(t, "hello", string(buf[:n]))
+}
+
+func Test_Setsize(t *testing.T) {
+	master, slave, err := Open()
+	require.NoError(t, err)
+	defer master.Close()
+	defer slave.Close()
+
+	require.NoError(t, Setsize(master, 24, 80))
+	rows, cols, err := Getsize(slave)
+	require.NoError(t, err)
+	require.Equal(t, uint16(24), rows)
+	require.Equal(t, uint16(80), cols)
+}
+
+func Test_MakeRawAndRestore(t *testing.T) {
+	master, slave, err := Open()
+	require.NoError(t, err)
+	defer master.Close()
+	defer slave.Close()
+
+	state, err := MakeRaw(slave)
+	require.NoError(t, err)
+	require.NoError(t, Restore(slave, state))
+}
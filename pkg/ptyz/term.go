@@ -0,0 +1,47 @@
+package ptyz
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// State is the terminal state returned by MakeRaw, used to restore the terminal.
+type State struct {
+	termios unix.Termios
+}
+
+// IsTerminal reports whether f is a terminal.
+func IsTerminal(f *os.File) bool {
+	_, err := unix.IoctlGetTermios(int(f.Fd()), unix.TCGETS)
+	return err == nil
+}
+
+// MakeRaw puts the terminal into raw mode, input is passed through byte by byte without echo or signal
+// generation. The returned State must be passed to Restore to return the terminal to its previous mode.
+func MakeRaw(f *os.File) (*State, error) {
+	fd := int(f.Fd())
+	termios, err := unix.IoctlGetTermios(fd, unix.TCGETS)
+	if err != nil {
+		return nil, err
+	}
+	old := State{termios: *termios}
+
+	// the same flags as cfmakeraw(3)
+	termios.Iflag &^= unix.IGNBRK | unix.BRKINT | unix.PARMRK | unix.ISTRIP | unix.INLCR | unix.IGNCR | unix.ICRNL | unix.IXON
+	termios.Oflag &^= unix.OPOST
+	termios.Lflag &^= unix.ECHO | unix.ECHONL | unix.ICANON | unix.ISIG | unix.IEXTEN
+	termios.Cflag &^= unix.CSIZE | unix.PARENB
+	termios.Cflag |= unix.CS8
+	termios.Cc[unix.VMIN] = 1
+	termios.Cc[unix.VTIME] = 0
+	if err := unix.IoctlSetTermios(fd, unix.TCSETS, termios); err != nil {
+		return nil, err
+	}
+	return &old, nil
+}
+
+// Restore returns the terminal to the state it was in before MakeRaw.
+func Restore(f *os.File, state *State) error {
+	return unix.IoctlSetTermios(int(f.Fd()), unix.TCSETS, &state.termios)
+}